@@ -9,8 +9,16 @@ import (
 type PerspectiveCamera struct {
 	BasicCamera
 	fovY float32
+	jitter math.Vec2
+	unjitteredProjMat math.Mat4
 	dirtyFrustumPlanes bool
 	frustumPlanes [6]*object.Plane
+
+	// physical lens parameters for depth of field, in world units
+	FocalDistance float32
+	FStop float32
+	SensorHeight float32
+	AutoFocus bool // focus on what is at the center of the screen
 }
 
 func NewPerspectiveCamera(fovYDeg, aspect, near, Far float32) *PerspectiveCamera {
@@ -20,9 +28,39 @@ func NewPerspectiveCamera(fovYDeg, aspect, near, Far float32) *PerspectiveCamera
 	c.updateViewMatrix()
 	c.updateProjectionMatrix()
 	c.dirtyFrustumPlanes = true
+	c.FocalDistance = 10
+	c.FStop = 2.8
+	c.SensorHeight = 0.024 // 35 mm film
 	return &c
 }
 
+// FocalLength returns the focal length of a lens with the field of view
+// of the camera on its sensor
+func (c *PerspectiveCamera) FocalLength() float32 {
+	return c.SensorHeight / 2 / float32(gomath.Tan(float64(c.fovY/2)))
+}
+
+// SetFocalLength changes the field of view to match a lens with the focal length
+func (c *PerspectiveCamera) SetFocalLength(f float32) {
+	c.fovY = 2 * float32(gomath.Atan(float64(c.SensorHeight/2/f)))
+	c.DirtyProjMat = true
+	c.dirtyFrustumPlanes = true
+}
+
+// smallest f-number, which keeps the aperture finite
+const minFStop = 0.5
+
+// ApertureDiameter returns the diameter of the lens opening
+func (c *PerspectiveCamera) ApertureDiameter() float32 {
+	return c.FocalLength() / math.Max(c.FStop, minFStop)
+}
+
+// FocusDistance returns FocalDistance, limited to distances the lens can
+// focus at beyond its focal length
+func (c *PerspectiveCamera) FocusDistance() float32 {
+	return math.Max(c.FocalDistance, 2*c.FocalLength())
+}
+
 func (c *PerspectiveCamera) Orient(unitX, unitY math.Vec3) {
 	c.BasicCamera.Orient(unitX, unitY)
 	c.dirtyFrustumPlanes = true
@@ -74,10 +112,32 @@ func (c *PerspectiveCamera) Translate(displacement math.Vec3) {
 }
 
 func (c *PerspectiveCamera) updateProjectionMatrix() {
-	c.projMat.Perspective(c.fovY, c.aspect, c.near, c.Far)
+	c.unjitteredProjMat.Perspective(c.fovY, c.aspect, c.near, c.Far)
+
+	// offset clip x and y by the jitter times w = -z
+	c.projMat = c.unjitteredProjMat
+	c.projMat.Set(0, 2, c.projMat.At(0, 2)-c.jitter.X())
+	c.projMat.Set(1, 2, c.projMat.At(1, 2)-c.jitter.Y())
+
 	c.DirtyProjMat = false
 }
 
+// SetJitter offsets the projection by a vector in normalized device
+// coordinates, e.g. by subpixel amounts for temporal antialiasing
+func (c *PerspectiveCamera) SetJitter(jitter math.Vec2) {
+	if jitter != c.jitter {
+		c.jitter = jitter
+		c.DirtyProjMat = true
+	}
+}
+
+func (c *PerspectiveCamera) UnjitteredProjectionMatrix() *math.Mat4 {
+	if c.DirtyProjMat {
+		c.updateProjectionMatrix()
+	}
+	return &c.unjitteredProjMat
+}
+
 func (c *PerspectiveCamera) ProjectionMatrix() *math.Mat4 {
 	if c.DirtyProjMat {
 		c.updateProjectionMatrix()
@@ -7,6 +7,7 @@ import (
 	"github.com/hersle/gl3d/window"
 	"github.com/hersle/gl3d/console"
 	"github.com/hersle/gl3d/render"
+	"github.com/hersle/gl3d/graphics"
 	"github.com/hersle/gl3d/input"
 	"github.com/hersle/gl3d/utils"
 	"time"
@@ -16,8 +17,10 @@ import (
 	"reflect"
 	"strconv"
 	"fmt"
+	"os"
 )
 var frames = flag.Int("frames", -1, "number of frames to run")
+var statsFile = flag.String("statscsv", "", "write render statistics of the last frames to CSV file on exit")
 
 type Engine struct {
 	Scene *scene.Scene
@@ -33,6 +36,8 @@ type Engine struct {
 
 	paused bool
 
+	ShowStats bool
+
 	frameCounter *utils.FrequencyCounter
 }
 
@@ -152,7 +157,15 @@ func (eng *Engine) Render() {
 	text := fmt.Sprintf("%d Hz\n%.1f ms", framerate, period)
 	eng.renderer.RenderText(math.Vec2{+1, +1}, text, 0.10, render.TopRight)
 
+	if graphics.Profile.Enabled {
+		eng.renderer.RenderText(math.Vec2{-1, -1}, graphics.Profile.String(), 0.05, render.BottomLeft)
+	}
+	if eng.ShowStats {
+		eng.renderer.RenderText(math.Vec2{+1, -1}, graphics.Stats.String(), 0.05, render.BottomRight)
+	}
+
 	eng.renderer.Render()
+	graphics.EndFrame()
 	window.Update()
 }
 
@@ -177,6 +190,30 @@ func (eng *Engine) Run() {
 
 		eng.frameCounter.Count()
 	}
+
+	if *statsFile != "" {
+		eng.writeStats(*statsFile)
+	}
+}
+
+func (eng *Engine) writeStats(filename string) {
+	// the console is gone by now, so report errors on stderr
+	file, err := os.Create(filename)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not write statistics:", err)
+		return
+	}
+	defer file.Close()
+
+	err = graphics.Stats.WriteCSV(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not write statistics:", err)
+	}
+}
+
+// AddPostEffect appends a full screen effect to the renderer's effect chain
+func (eng *Engine) AddPostEffect(e render.PostEffect) {
+	eng.renderer.AddPostEffect(e)
 }
 
 func (eng *Engine) ExecuteCommand(cmd string) {
@@ -187,13 +224,116 @@ func (eng *Engine) ExecuteCommand(cmd string) {
 		return
 	}
 
-	// get pointer to field value
+	// commands that load files
+	switch fields[0] {
+	case "lut", "lut2":
+		eng.loadColorLUT(fields)
+		return
+	}
+
+	// get pointer to field value, and the largest value of enumerations
 	var ptr interface{}
+	maxEnum := -1
 	switch fields[0] {
 	case "fog":
-		ptr = &eng.renderer.Fog
+		ptr = &eng.renderer.Fog.Enabled
+	case "fogmode":
+		ptr = (*int)(&eng.renderer.Fog.Mode)
+		maxEnum = int(render.SquaredExponentialFog)
+	case "fogred":
+		ptr = &eng.renderer.Fog.Color[0]
+	case "foggreen":
+		ptr = &eng.renderer.Fog.Color[1]
+	case "fogblue":
+		ptr = &eng.renderer.Fog.Color[2]
+	case "fogdensity":
+		ptr = &eng.renderer.Fog.Density
+	case "fogstart":
+		ptr = &eng.renderer.Fog.Start
+	case "fogend":
+		ptr = &eng.renderer.Fog.End
+	case "fogheightdensity":
+		ptr = &eng.renderer.Fog.HeightDensity
+	case "fogheightfalloff":
+		ptr = &eng.renderer.Fog.HeightFalloff
+	case "fogheight":
+		ptr = &eng.renderer.Fog.Height
+	case "fogskyboxtint":
+		ptr = &eng.renderer.Fog.SkyboxTint
+	case "volumetric":
+		ptr = &eng.renderer.VolumetricLight.Enabled
+	case "volumetricdensity":
+		ptr = &eng.renderer.VolumetricLight.Density
+	case "volumetricanisotropy":
+		ptr = &eng.renderer.VolumetricLight.Anisotropy
+	case "volumetricsteps":
+		ptr = &eng.renderer.VolumetricLight.Steps
+	case "volumetricdistance":
+		ptr = &eng.renderer.VolumetricLight.MaxDistance
 	case "blurradius":
 		ptr = &eng.renderer.BlurRadius
+	case "reflections":
+		ptr = &eng.renderer.Reflections.Enabled
+	case "reflectionintensity":
+		ptr = &eng.renderer.Reflections.Intensity
+	case "reflectionsteps":
+		ptr = &eng.renderer.Reflections.MaxSteps
+	case "reflectionrefinesteps":
+		ptr = &eng.renderer.Reflections.RefineSteps
+	case "reflectionstepsize":
+		ptr = &eng.renderer.Reflections.StepSize
+	case "reflectionthickness":
+		ptr = &eng.renderer.Reflections.Thickness
+	case "reflectiondebug":
+		ptr = &eng.renderer.Reflections.Debug
+	case "outline":
+		ptr = &eng.renderer.Outline.Enabled
+	case "outlinethickness":
+		ptr = &eng.renderer.Outline.Thickness
+	case "outlinedepththreshold":
+		ptr = &eng.renderer.Outline.DepthThreshold
+	case "outlinenormalthreshold":
+		ptr = &eng.renderer.Outline.NormalThreshold
+	case "depthoffield":
+		ptr = &eng.renderer.DepthOfField
+	case "dofmaxradius":
+		ptr = &eng.renderer.DepthOfFieldMaxRadius
+	case "focaldistance":
+		ptr = &eng.Camera.FocalDistance
+	case "fstop":
+		ptr = &eng.Camera.FStop
+	case "autofocus":
+		ptr = &eng.Camera.AutoFocus
+	case "motionblur":
+		ptr = &eng.renderer.MotionBlur
+	case "objectmotionblur":
+		ptr = &eng.renderer.ObjectMotionBlur
+	case "shutter":
+		ptr = &eng.renderer.MotionBlurShutter
+	case "bloom":
+		ptr = &eng.renderer.Bloom
+	case "bloomthreshold":
+		ptr = &eng.renderer.BloomThreshold
+	case "bloomintensity":
+		ptr = &eng.renderer.BloomIntensity
+	case "bloomradius":
+		ptr = &eng.renderer.BloomRadius
+	case "antialiasing":
+		ptr = (*int)(&eng.renderer.Antialiasing)
+		maxEnum = int(render.TemporalAntialiasing)
+	case "temporalblend":
+		ptr = &eng.renderer.TemporalBlend
+	case "lutblend":
+		ptr = &eng.renderer.ColorLUTBlend
+	case "tonemapping":
+		ptr = (*int)(&eng.renderer.ToneMapping)
+		maxEnum = int(render.ACESToneMapping)
+	case "exposure":
+		ptr = &eng.renderer.Exposure
+	case "autoexposure":
+		ptr = &eng.renderer.AutoExposure
+	case "exposureadaptation":
+		ptr = &eng.renderer.ExposureAdaptation
 	case "shadowkernelsize":
 		ptr = &eng.renderer.MeshRenderer.ShadowKernelSize
 	case "materialambient":
@@ -212,6 +352,28 @@ func (eng *Engine) ExecuteCommand(cmd string) {
 		ptr = &eng.renderer.MeshRenderer.Wireframe
 	case "ambientocclusion":
 		ptr = &eng.renderer.MeshRenderer.AmbientOcclusion
+	case "aoradius":
+		ptr = &eng.renderer.MeshRenderer.AmbientOcclusionRadius
+	case "aosamples":
+		ptr = &eng.renderer.MeshRenderer.AmbientOcclusionSamples
+	case "aobias":
+		ptr = &eng.renderer.MeshRenderer.AmbientOcclusionBias
+	case "aopower":
+		ptr = &eng.renderer.MeshRenderer.AmbientOcclusionPower
+	case "aohalfres":
+		ptr = &eng.renderer.MeshRenderer.AmbientOcclusionHalfResolution
+	case "toon":
+		ptr = &eng.renderer.MeshRenderer.Toon
+	case "toonbands":
+		ptr = &eng.renderer.MeshRenderer.ToonBands
+	case "occlusionculling":
+		ptr = &eng.renderer.MeshRenderer.OcclusionCulling
+	case "clusteredshading":
+		ptr = &eng.renderer.MeshRenderer.ClusteredShading
+	case "profiler":
+		ptr = &graphics.Profile.Enabled
+	case "stats":
+		ptr = &eng.ShowStats
 	default:
 		log.Print("invalid field: ", fields[0])
 		return
@@ -241,6 +403,10 @@ func (eng *Engine) ExecuteCommand(cmd string) {
 		case *int:
 			ptr := ptr.(*int)
 			val, err := strconv.ParseInt(fields[1], 0, 0)
+			if err == nil && maxEnum >= 0 && (val < 0 || val > int64(maxEnum)) {
+				log.Print("invalid value: ", fields[1], " (must be 0 to ", maxEnum, ")")
+				return
+			}
 			if err == nil {
 				*ptr = int(val)
 			} else {
@@ -254,3 +420,33 @@ func (eng *Engine) ExecuteCommand(cmd string) {
 	val := reflect.Indirect(reflect.ValueOf(ptr)).Interface()
 	log.Print(fields[0], ": ", val)
 }
+
+// loadColorLUT sets a color grading lookup table from a file, or removes it
+// with "off"
+func (eng *Engine) loadColorLUT(fields []string) {
+	var target **render.ColorLUT
+	if fields[0] == "lut2" {
+		target = &eng.renderer.ColorLUT2
+	} else {
+		target = &eng.renderer.ColorLUT
+	}
+
+	if len(fields) != 2 {
+		log.Print("usage: ", fields[0], " filename|off")
+		return
+	}
+
+	if fields[1] == "off" {
+		*target = nil
+		log.Print(fields[0], ": off")
+		return
+	}
+
+	lut, err := render.ReadColorLUT(fields[1])
+	if err != nil {
+		log.Print(err)
+		return
+	}
+	*target = lut
+	log.Print(fields[0], ": ", fields[1])
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/hersle/gl3d/math"
 	"github.com/hersle/gl3d/light"
 	"github.com/hersle/gl3d/input"
+	"github.com/hersle/gl3d/render"
+	"github.com/hersle/gl3d/graphics"
 	"flag"
 	"runtime/pprof"
 	"os"
@@ -14,6 +16,8 @@ import (
 var cpuprofile = flag.String("cpuprofile", "", "write CPU profile to file")
 
 func main() {
+	flag.StringVar(&render.ShaderDir, "shaderdir", "", "read shaders from directory instead of those embedded in the binary")
+	flag.StringVar(&graphics.ProgramBinaryDir, "programcache", "", "cache linked shader programs in directory between runs")
 	flag.Parse()
 
 	if *cpuprofile != "" {
@@ -30,7 +34,7 @@ func main() {
 	eng.InitializeCustom = func() {
 		eng.Scene.AddAmbientLight(light.NewAmbientLight(math.Vec3{0.5, 0.5, 0.5}))
 		eng.Scene.AddPointLight(light.NewPointLight(math.Vec3{1, 1, 1}))
-		eng.Scene.PointLights[0].Attenuation = 0.001
+		eng.Scene.PointLights[0].AttenuationQuadratic = 0.001
 		for _, filename := range flag.Args() {
 			model, err := object.ReadMesh(filename)
 			if err != nil {
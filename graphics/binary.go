@@ -0,0 +1,125 @@
+package graphics
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"github.com/go-gl/gl/v4.5-core/gl"
+	"log"
+	"os"
+	"path/filepath"
+	"unsafe"
+)
+
+// ProgramBinaryDir is where linked program binaries are cached between runs,
+// so programs need not be compiled from source on every start.
+// The cache is disabled when it is "".
+var ProgramBinaryDir string
+
+// programBinaryKey identifies a program by the stages and preprocessed
+// sources (which include the defines) of its shaders and the driver,
+// since binaries are driver specific. It is "" when the cache is disabled.
+func programBinaryKey(types []uint32, srcs []*shaderSource) string {
+	if ProgramBinaryDir == "" {
+		return ""
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(driverString()))
+	for i, src := range srcs {
+		var stage [4]byte
+		binary.LittleEndian.PutUint32(stage[:], types[i])
+		hash.Write([]byte{0})
+		hash.Write(stage[:])
+		hash.Write([]byte(src.src))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func driverString() string {
+	vendor := gl.GoStr(gl.GetString(gl.VENDOR))
+	renderer := gl.GoStr(gl.GetString(gl.RENDERER))
+	version := gl.GoStr(gl.GetString(gl.VERSION))
+	return vendor + "\n" + renderer + "\n" + version
+}
+
+func programBinaryFormatCount() int {
+	var count int32
+	gl.GetIntegerv(gl.NUM_PROGRAM_BINARY_FORMATS, &count)
+	return int(count)
+}
+
+func programBinaryPath(key string) string {
+	return filepath.Join(ProgramBinaryDir, key+".bin")
+}
+
+// loadBinary links the program from a cached binary,
+// and reports whether the driver accepted it
+func (prog *Program) loadBinary(key string) bool {
+	if ProgramBinaryDir == "" || programBinaryFormatCount() == 0 {
+		return false
+	}
+
+	// file layout: 4 byte little endian binary format, then the binary
+	data, err := os.ReadFile(programBinaryPath(key))
+	if err != nil || len(data) <= 4 {
+		return false
+	}
+	format := binary.LittleEndian.Uint32(data[:4])
+	data = data[4:]
+
+	gl.ProgramBinary(prog.id, format, unsafe.Pointer(&data[0]), int32(len(data)))
+	return prog.linked()
+}
+
+func (prog *Program) saveBinary(key string) {
+	if ProgramBinaryDir == "" || programBinaryFormatCount() == 0 {
+		return
+	}
+
+	var length int32
+	gl.GetProgramiv(prog.id, gl.PROGRAM_BINARY_LENGTH, &length)
+	if length == 0 {
+		return
+	}
+
+	var format uint32
+	data := make([]byte, 4+length)
+	gl.GetProgramBinary(prog.id, length, &length, &format, unsafe.Pointer(&data[4]))
+	binary.LittleEndian.PutUint32(data[:4], format)
+	data = data[:4+length]
+
+	err := writeFileAtomic(programBinaryPath(key), data)
+	if err != nil {
+		log.Print("could not cache program binary: ", err)
+	}
+}
+
+// writeFileAtomic writes through a temporary file that is renamed in place,
+// so concurrent runs never read a partially written file
+func writeFileAtomic(filename string, data []byte) error {
+	dir := filepath.Dir(filename)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(dir, filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if err1 := file.Close(); err == nil {
+		err = err1
+	}
+	if err == nil {
+		err = os.Chmod(file.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), filename)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+	}
+	return err
+}
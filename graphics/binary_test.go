@@ -0,0 +1,42 @@
+package graphics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "programs")
+	filename := filepath.Join(dir, "key.bin")
+
+	// the directory is created, and an existing file is replaced
+	for _, contents := range []string{"first", "second"} {
+		err := writeFileAtomic(filename, []byte(contents))
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != contents {
+			t.Errorf("read %q, expected %q", data, contents)
+		}
+	}
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "key.bin" {
+		t.Errorf("got directory entries %v, expected only key.bin", entries)
+	}
+
+	// the directory cannot be created below a file
+	err = writeFileAtomic(filepath.Join(filename, "below-a-file.bin"), []byte("x"))
+	if err == nil {
+		t.Errorf("expected an error when writing below a file")
+	}
+}
@@ -22,6 +22,11 @@ type IndexBuffer struct {
 	index reflect.Type
 }
 
+// StorageBuffer holds arbitrary data read by shader storage blocks
+type StorageBuffer struct {
+	buffer
+}
+
 func newBuffer() *buffer {
 	var buf buffer
 	gl.CreateBuffers(1, &buf.id)
@@ -142,6 +147,22 @@ func (buf *IndexBuffer) elementGlType() uint32 {
 	}
 }
 
+func NewStorageBuffer() *StorageBuffer {
+	var buf StorageBuffer
+	buf.buffer = *newBuffer()
+	return &buf
+}
+
+// SetData replaces the contents of the buffer, growing it without preserving
+// old contents if necessary
+func (buf *StorageBuffer) SetData(data interface{}) {
+	bytes := byteSlice(data)
+	if len(bytes) > buf.size {
+		buf.Allocate(len(bytes))
+	}
+	buf.SetBytes(bytes, 0)
+}
+
 func byteSlice(data interface{}) []byte {
 	val := reflect.ValueOf(data)
 	if val.Kind() != reflect.Slice {
@@ -0,0 +1,21 @@
+package graphics
+
+// BeginPass marks the start of a named rendering pass,
+// which is profiled and counted separately in the statistics
+func BeginPass(name string) {
+	Profile.Begin(name)
+	Stats.beginPass(name)
+}
+
+// EndPass marks the end of the pass started with BeginPass
+func EndPass() {
+	Profile.End()
+	Stats.endPass()
+}
+
+// EndFrame should be called once after each frame
+// to update the profiler and reset the statistics
+func EndFrame() {
+	Profile.EndFrame()
+	Stats.EndFrame()
+}
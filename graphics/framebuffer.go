@@ -1,6 +1,7 @@
 package graphics
 
 import (
+	"fmt"
 	"github.com/go-gl/gl/v4.5-core/gl"
 	"github.com/hersle/gl3d/math"
 	"github.com/hersle/gl3d/window"
@@ -9,21 +10,25 @@ import (
 type framebuffer struct {
 	id            uint32
 	width, height int
+	attachments   map[uint32]RenderTarget
+	dirty         bool // attachments changed since the last draw
 }
 
-type renderTarget interface {
-	attachTo(f *framebuffer)
+// RenderTarget is a texture that programs can render to
+type RenderTarget interface {
+	attachTo(f *framebuffer) uint32 // returns the attachment point
 	Width() int
 	Height() int
 }
 
-var defaultFramebuffer *framebuffer = &framebuffer{0, 800, 800}
+var defaultFramebuffer *framebuffer = &framebuffer{0, 800, 800, nil, false}
 
 func newFramebuffer() *framebuffer {
 	var fb framebuffer
 	gl.CreateFramebuffers(1, &fb.id)
 	fb.width = 0
 	fb.height = 0
+	fb.attachments = make(map[uint32]RenderTarget)
 	return &fb
 }
 
@@ -42,10 +47,12 @@ func (fb *framebuffer) Height() int {
 }
 
 func (fb *framebuffer) clearColor(rgba math.Vec4) {
+	unmaskWrites()
 	gl.ClearNamedFramebufferfv(fb.id, gl.COLOR, 0, &rgba[0])
 }
 
 func (fb *framebuffer) clearDepth(depth float32) {
+	unmaskWrites()
 	gl.ClearNamedFramebufferfv(fb.id, gl.DEPTH, 0, &depth)
 }
 
@@ -54,14 +61,36 @@ func (fb *framebuffer) clearStencil(index int) {
 	gl.ClearNamedFramebufferiv(fb.id, gl.STENCIL, 0, &value)
 }
 
-func (fb *framebuffer) attach(target renderTarget) {
-	if fb.width == 0 && fb.height == 0 {
-		fb.width = target.Width()
-		fb.height = target.Height()
-	} else if fb.width != target.Width() || fb.height != target.Height() {
-		panic("incompatible framebuffer attachment size")
+// attach replaces the target at its attachment point. Sizes are only checked
+// when drawing, so attachments can be replaced one at a time in any order.
+func (fb *framebuffer) attach(target RenderTarget) {
+	att := target.attachTo(fb)
+	fb.attachments[att] = target
+	fb.dirty = true
+}
+
+// validate checks that all attachments have the same size before drawing,
+// and reports whether the size of the framebuffer changed
+func (fb *framebuffer) validate() (bool, error) {
+	if !fb.dirty {
+		return false, nil
+	}
+
+	width, height := -1, -1
+	for _, target := range fb.attachments {
+		if width == -1 {
+			width, height = target.Width(), target.Height()
+		} else if target.Width() != width || target.Height() != height {
+			return false, fmt.Errorf("incompatible framebuffer attachment sizes %dx%d and %dx%d", width, height, target.Width(), target.Height())
+		}
+	}
+	fb.dirty = false
+
+	if width == -1 || (width == fb.width && height == fb.height) {
+		return false, nil
 	}
-	target.attachTo(fb)
+	fb.width, fb.height = width, height
+	return true, nil
 }
 
 func (fb *framebuffer) complete() bool {
@@ -0,0 +1,51 @@
+package graphics
+
+import (
+	"testing"
+)
+
+// testTarget is a render target that only has a size
+type testTarget struct {
+	att           uint32
+	width, height int
+}
+
+func (t *testTarget) attachTo(f *framebuffer) uint32 {
+	return t.att
+}
+
+func (t *testTarget) Width() int {
+	return t.width
+}
+
+func (t *testTarget) Height() int {
+	return t.height
+}
+
+func TestFramebufferValidate(t *testing.T) {
+	fb := &framebuffer{attachments: make(map[uint32]RenderTarget)}
+
+	fb.attach(&testTarget{0, 64, 32})
+	fb.attach(&testTarget{1, 64, 32})
+	resized, err := fb.validate()
+	if err != nil || !resized || fb.width != 64 || fb.height != 32 {
+		t.Errorf("got resized %t and error %v with size %dx%d, expected a resize to 64x32", resized, err, fb.width, fb.height)
+	}
+
+	// replacing one attachment at a time only fails if drawn in between
+	fb.attach(&testTarget{0, 16, 16})
+	_, err = fb.validate()
+	if err == nil {
+		t.Errorf("expected an error for attachments of different sizes")
+	}
+	fb.attach(&testTarget{1, 16, 16})
+	resized, err = fb.validate()
+	if err != nil || !resized || fb.width != 16 || fb.height != 16 {
+		t.Errorf("got resized %t and error %v with size %dx%d, expected a resize to 16x16", resized, err, fb.width, fb.height)
+	}
+
+	resized, err = fb.validate()
+	if err != nil || resized {
+		t.Errorf("got resized %t and error %v without changes, expected neither", resized, err)
+	}
+}
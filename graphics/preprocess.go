@@ -0,0 +1,167 @@
+package graphics
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// shaderSource is GLSL source with defines inserted and #include directives
+// resolved. Every file gets its own source string number in #line directives,
+// so compiler messages can be mapped back to the original file and line.
+type shaderSource struct {
+	src   string
+	files []string // file name of each source string number
+}
+
+// ProgramCache lazily builds permutations of one set of shader templates,
+// so renderers can request any combination of defines when they need it
+type ProgramCache struct {
+	fsys     fs.FS
+	files    []string
+	srcs     []string
+	programs map[string]*Program
+	errs     map[string]error // permutations that failed to build
+}
+
+// matches "0:12" (Mesa, AMD) and "0(12)" (NVIDIA) locations in compiler logs
+var shaderLogLocation = regexp.MustCompile(`(\d+)[:(](\d+)\)?`)
+
+func preprocessShader(fsys fs.FS, name, src string, defines []string) (*shaderSource, error) {
+	var ss shaderSource
+	var b strings.Builder
+
+	lines := strings.Split(src, "\n")
+	b.WriteString(lines[0] + "\n") // #version
+	for _, define := range defines {
+		b.WriteString("#define " + define + "\n")
+	}
+	b.WriteString("#line 2 0\n")
+
+	ss.files = append(ss.files, name)
+	included := map[string]bool{name: true}
+	err := ss.include(&b, fsys, name, lines[1:], 2, 0, included)
+	if err != nil {
+		return nil, err
+	}
+
+	ss.src = b.String()
+	return &ss, nil
+}
+
+// include writes lines from file name starting at line number firstLine,
+// recursively replacing #include "file" with the contents of file (once)
+func (ss *shaderSource) include(b *strings.Builder, fsys fs.FS, name string, lines []string, firstLine, fileIndex int, included map[string]bool) error {
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != "#include" {
+			b.WriteString(line + "\n")
+			continue
+		}
+
+		if fsys == nil {
+			return fmt.Errorf("%s:%d: #include with no file system to read from", name, firstLine+i)
+		}
+
+		incName := path.Join(path.Dir(name), strings.Trim(fields[1], "\"<>"))
+		if included[incName] {
+			b.WriteString("\n") // keep line numbering
+			continue
+		}
+		included[incName] = true
+
+		src, err := fs.ReadFile(fsys, incName)
+		if err != nil {
+			return fmt.Errorf("%s:%d: %v", name, firstLine+i, err)
+		}
+
+		incIndex := len(ss.files)
+		ss.files = append(ss.files, incName)
+		fmt.Fprintf(b, "#line 1 %d\n", incIndex)
+		err = ss.include(b, fsys, incName, strings.Split(string(src), "\n"), 1, incIndex, included)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "#line %d %d\n", firstLine+i+1, fileIndex)
+	}
+	return nil
+}
+
+// mapLog replaces source string numbers in a compiler log with file names
+func (ss *shaderSource) mapLog(log string) string {
+	lines := strings.Split(log, "\n")
+	for i, line := range lines {
+		loc := shaderLogLocation.FindStringSubmatchIndex(line)
+		if loc == nil {
+			continue
+		}
+		fileIndex, err := strconv.Atoi(line[loc[2]:loc[3]])
+		if err != nil || fileIndex >= len(ss.files) || ss.files[fileIndex] == "" {
+			continue
+		}
+		file := ss.files[fileIndex]
+		lineNumber := line[loc[4]:loc[5]]
+		lines[i] = line[:loc[0]] + file + ":" + lineNumber + line[loc[1]:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func NewProgramCache(fsys fs.FS, vFile, fFile, gFile string) *ProgramCache {
+	var cache ProgramCache
+	cache.fsys = fsys
+	cache.files = []string{vFile, fFile, gFile}
+	cache.srcs = make([]string, 3)
+	for i, file := range cache.files {
+		if file == "" {
+			continue
+		}
+		src, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			panic(err)
+		}
+		cache.srcs[i] = string(src)
+	}
+	cache.programs = make(map[string]*Program)
+	cache.errs = make(map[string]error)
+	return &cache
+}
+
+// Program returns the permutation with the given defines, building it on first use.
+// A permutation that fails to build returns the same error without rebuilding.
+func (cache *ProgramCache) Program(defines ...string) (*Program, error) {
+	defines = sortedDefines(defines)
+	key := strings.Join(defines, " ")
+	if err, failed := cache.errs[key]; failed {
+		return nil, err
+	}
+	prog, found := cache.programs[key]
+	if !found {
+		var err error
+		prog, err = newProgram(cache.fsys, cache.files, cache.srcs, defines)
+		if err != nil {
+			cache.errs[key] = err
+			return nil, err
+		}
+		cache.programs[key] = prog
+	}
+	return prog, nil
+}
+
+// sortedDefines returns a sorted copy of defines without duplicates,
+// so the same set in any order identifies the same permutation
+func sortedDefines(defines []string) []string {
+	sorted := append([]string(nil), defines...)
+	sort.Strings(sorted)
+
+	unique := sorted[:0]
+	for _, define := range sorted {
+		if len(unique) == 0 || define != unique[len(unique)-1] {
+			unique = append(unique, define)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,123 @@
+package graphics
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestPreprocessNestedIncludes(t *testing.T) {
+	fsys := fstest.MapFS{
+		"inc/a.glsl": {Data: []byte("#include \"b.glsl\"\nfloat a;")},
+		"inc/b.glsl": {Data: []byte("float b;")},
+	}
+	src := "#version 450\n#include \"inc/a.glsl\"\nvoid main() {}"
+
+	ss, err := preprocessShader(fsys, "main.glsl", src, []string{"X"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.Join([]string{
+		"#version 450",
+		"#define X",
+		"#line 2 0",
+		"#line 1 1",
+		"#line 1 2",
+		"float b;",
+		"#line 2 1",
+		"float a;",
+		"#line 3 0",
+		"void main() {}",
+		"",
+	}, "\n")
+	if ss.src != expected {
+		t.Errorf("got source\n%s\nexpected\n%s", ss.src, expected)
+	}
+
+	files := []string{"main.glsl", "inc/a.glsl", "inc/b.glsl"}
+	if !reflect.DeepEqual(ss.files, files) {
+		t.Errorf("got files %v, expected %v", ss.files, files)
+	}
+}
+
+func TestPreprocessIncludeCycle(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.glsl": {Data: []byte("#include \"b.glsl\"\nfloat a;")},
+		"b.glsl": {Data: []byte("#include \"a.glsl\"\nfloat b;")},
+	}
+	src := "#version 450\n#include \"a.glsl\""
+
+	ss, err := preprocessShader(fsys, "main.glsl", src, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the second #include "a.glsl" is replaced by an empty line
+	expected := strings.Join([]string{
+		"#version 450",
+		"#line 2 0",
+		"#line 1 1",
+		"#line 1 2",
+		"",
+		"float b;",
+		"#line 2 1",
+		"float a;",
+		"#line 3 0",
+		"",
+	}, "\n")
+	if ss.src != expected {
+		t.Errorf("got source\n%s\nexpected\n%s", ss.src, expected)
+	}
+}
+
+func TestPreprocessMissingInclude(t *testing.T) {
+	src := "#version 450\n\n#include \"missing.glsl\""
+
+	_, err := preprocessShader(fstest.MapFS{}, "main.glsl", src, nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "main.glsl:3:") {
+		t.Errorf("got error %v, expected one at main.glsl:3", err)
+	}
+
+	_, err = preprocessShader(nil, "main.glsl", src, nil)
+	if err == nil {
+		t.Errorf("expected error when including with no file system")
+	}
+}
+
+func TestMapLog(t *testing.T) {
+	ss := shaderSource{files: []string{"main.glsl", "lighting.glsl"}}
+
+	tests := []struct {
+		log, expected string
+	}{
+		{"0:12(3): error: x", "main.glsl:12(3): error: x"},
+		{"ERROR: 1:4: y", "ERROR: lighting.glsl:4: y"},
+		{"1(7) : error C0000: z", "lighting.glsl:7 : error C0000: z"},
+		{"5:1: unknown source string", "5:1: unknown source string"},
+		{"no location", "no location"},
+	}
+	for _, test := range tests {
+		got := ss.mapLog(test.log)
+		if got != test.expected {
+			t.Errorf("mapLog(%q) = %q, expected %q", test.log, got, test.expected)
+		}
+	}
+}
+
+func TestSortedDefines(t *testing.T) {
+	defines := []string{"SHADOW", "AREA", "SHADOW", "BUMP"}
+	sorted := sortedDefines(defines)
+
+	expected := []string{"AREA", "BUMP", "SHADOW"}
+	if !reflect.DeepEqual(sorted, expected) {
+		t.Errorf("got %v, expected %v", sorted, expected)
+	}
+	if defines[0] != "SHADOW" || defines[1] != "AREA" {
+		t.Errorf("sortedDefines modified its argument: %v", defines)
+	}
+	if len(sortedDefines(nil)) != 0 {
+		t.Errorf("expected no defines")
+	}
+}
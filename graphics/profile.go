@@ -0,0 +1,141 @@
+package graphics
+
+import (
+	"fmt"
+	"time"
+)
+
+// Profiler measures the CPU and GPU time of named rendering passes,
+// averaged over a number of frames
+type Profiler struct {
+	Enabled  bool
+	Interval int // number of frames to average over
+
+	passes  map[string]*profiledPass
+	order   []string // pass names in the order they first ran
+	current *profiledPass
+	frames  int
+}
+
+type PassTiming struct {
+	Name string
+	CPU  time.Duration
+	GPU  time.Duration
+}
+
+type profiledPass struct {
+	timing PassTiming // averages from the last completed interval
+
+	cpuStart time.Time
+	cpuTotal time.Duration
+	gpuTotal time.Duration
+
+	// queries are read some frames after they were issued to avoid stalls
+	queries []*TimerQuery
+	pending []*TimerQuery
+}
+
+var Profile *Profiler = NewProfiler()
+
+func NewProfiler() *Profiler {
+	var p Profiler
+	p.Enabled = false
+	p.Interval = 30
+	p.passes = make(map[string]*profiledPass)
+	return &p
+}
+
+func (p *Profiler) Begin(name string) {
+	if !p.Enabled {
+		return
+	}
+	if p.current != nil {
+		p.End() // timer queries can not be nested
+	}
+
+	pass, found := p.passes[name]
+	if !found {
+		pass = &profiledPass{}
+		pass.timing.Name = name
+		p.passes[name] = pass
+		p.order = append(p.order, name)
+	}
+
+	var q *TimerQuery
+	if len(pass.queries) > 0 {
+		q = pass.queries[len(pass.queries)-1]
+		pass.queries = pass.queries[:len(pass.queries)-1]
+	} else {
+		q = NewTimerQuery()
+	}
+	pass.pending = append(pass.pending, q)
+	q.Begin()
+
+	pass.cpuStart = time.Now()
+	p.current = pass
+}
+
+func (p *Profiler) End() {
+	if !p.Enabled || p.current == nil {
+		return
+	}
+
+	pass := p.current
+	pass.cpuTotal += time.Since(pass.cpuStart)
+	pass.pending[len(pass.pending)-1].End()
+	p.current = nil
+}
+
+// EndFrame collects finished GPU measurements
+// and updates the averages once every Interval frames
+func (p *Profiler) EndFrame() {
+	if !p.Enabled {
+		return
+	}
+	p.End()
+
+	for _, pass := range p.passes {
+		i := 0
+		for ; i < len(pass.pending) && pass.pending[i].Available(); i++ {
+			pass.gpuTotal += pass.pending[i].Duration()
+			pass.queries = append(pass.queries, pass.pending[i])
+		}
+		pass.pending = pass.pending[i:]
+	}
+
+	p.frames++
+	if p.frames >= p.Interval {
+		for _, pass := range p.passes {
+			pass.timing.CPU = pass.cpuTotal / time.Duration(p.frames)
+			pass.timing.GPU = pass.gpuTotal / time.Duration(p.frames)
+			pass.cpuTotal = 0
+			pass.gpuTotal = 0
+		}
+		p.frames = 0
+	}
+}
+
+// Timings returns the average time of each pass, in the order they run
+func (p *Profiler) Timings() []PassTiming {
+	timings := make([]PassTiming, len(p.order))
+	for i, name := range p.order {
+		timings[i] = p.passes[name].timing
+	}
+	return timings
+}
+
+func (p *Profiler) String() string {
+	text := "pass      cpu ms  gpu ms"
+	var cpu, gpu time.Duration
+	for _, timing := range p.Timings() {
+		text += fmt.Sprintf("\n%-8s %7.2f %7.2f", timing.Name, milliseconds(timing.CPU), milliseconds(timing.GPU))
+		cpu += timing.CPU
+		gpu += timing.GPU
+	}
+	text += fmt.Sprintf("\n%-8s %7.2f %7.2f", "total", milliseconds(cpu), milliseconds(gpu))
+	return text
+}
+
+func milliseconds(d time.Duration) float64 {
+	return d.Seconds() * 1000
+}
@@ -0,0 +1,76 @@
+package graphics
+
+import (
+	"github.com/go-gl/gl/v4.5-core/gl"
+	"time"
+)
+
+type query struct {
+	id     uint32
+	target uint32
+}
+
+// TimerQuery measures the GPU time spent on the commands between Begin and End
+type TimerQuery struct {
+	query
+}
+
+// OcclusionQuery tests whether any samples pass the depth test between Begin and End
+type OcclusionQuery struct {
+	query
+}
+
+func newQuery(target uint32) *query {
+	var q query
+	q.target = target
+	gl.CreateQueries(target, 1, &q.id)
+	return &q
+}
+
+// Delete frees the query, which must not be used afterwards
+func (q *query) Delete() {
+	gl.DeleteQueries(1, &q.id)
+}
+
+func (q *query) Begin() {
+	gl.BeginQuery(q.target, q.id)
+}
+
+func (q *query) End() {
+	gl.EndQuery(q.target)
+}
+
+// Available reports whether the result can be read without waiting for the GPU
+func (q *query) Available() bool {
+	var available uint32
+	gl.GetQueryObjectuiv(q.id, gl.QUERY_RESULT_AVAILABLE, &available)
+	return available == gl.TRUE
+}
+
+func (q *query) result() uint64 {
+	var result uint64
+	gl.GetQueryObjectui64v(q.id, gl.QUERY_RESULT, &result)
+	return result
+}
+
+func NewTimerQuery() *TimerQuery {
+	var q TimerQuery
+	q.query = *newQuery(gl.TIME_ELAPSED)
+	return &q
+}
+
+// Duration waits for and returns the measured time
+func (q *TimerQuery) Duration() time.Duration {
+	return time.Duration(q.result()) // nanoseconds
+}
+
+func NewOcclusionQuery() *OcclusionQuery {
+	var q OcclusionQuery
+	q.query = *newQuery(gl.ANY_SAMPLES_PASSED_CONSERVATIVE)
+	return &q
+}
+
+// Visible waits for and returns whether any samples passed
+func (q *OcclusionQuery) Visible() bool {
+	return q.result() != 0
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"github.com/go-gl/gl/v4.5-core/gl"
 	"github.com/hersle/gl3d/math"
-	"io/ioutil"
+	"io/fs"
+	"log"
+	"os"
 	"strings"
 	"fmt"
 )
@@ -27,6 +29,10 @@ type Program struct {
 
 	outputColorsByLocation map[uint32]*Output
 	outputColorLocationsByName map[string]uint32
+
+	// units are allocated per program from 0, so the textures are rebound
+	// when switching to the program
+	textures []uint32
 }
 
 type Input struct {
@@ -54,27 +60,28 @@ type Uniform struct {
 	textureUnitIndex uint32
 }
 
+// StorageBlock is a shader storage block that reads from a StorageBuffer
+type StorageBlock struct {
+	prog    *Program
+	index   uint32
+	name    string
+	binding uint32
+}
+
 var currentProg *Program
 
-func newShader(type_ uint32, src string, defines ...string) *shader {
+var storageBindingsUsed = 0
+
+func newShader(type_ uint32, src *shaderSource) (*shader, error) {
 	var sh shader
 	sh.id = gl.CreateShader(type_)
-
-	lines := strings.Split(src, "\n")
-	src = lines[0] + "\n" // #version
-	for _, define := range defines {
-		src = src + "#define " + define + "\n"
-	}
-	for _, line := range lines[1:] {
-		src = src + line + "\n"
-	}
-	sh.setSource(src)
+	sh.setSource(src.src)
 
 	err := sh.compile()
 	if err != nil {
-		panic(err)
+		return nil, errors.New(src.mapLog(err.Error()))
 	}
-	return &sh
+	return &sh, nil
 }
 
 func (sh *shader) setSource(src string) {
@@ -108,20 +115,21 @@ func (sh *shader) compile() error {
 	}
 }
 
-var textureUnitsUsed int = 0
-
-func buildProgram(shaders ...*shader) *Program {
+// buildProgram compiles and links a program, or deletes it and returns the
+// error if a shader does not compile or it uses too many texture units
+func buildProgram(types []uint32, srcs []*shaderSource) (*Program, error) {
 	var prog Program
 	prog.id = gl.CreateProgram()
 
-	for _, sh := range shaders {
-		gl.AttachShader(prog.id, sh.id)
-		defer gl.DetachShader(prog.id, sh.id)
-	}
-
-	err := prog.link()
-	if err != nil {
-		panic(err)
+	// compile from source only if there is no usable cached binary
+	key := programBinaryKey(types, srcs)
+	if !prog.loadBinary(key) {
+		err := prog.compile(types, srcs)
+		if err != nil {
+			gl.DeleteProgram(prog.id)
+			return nil, err
+		}
+		prog.saveBinary(key)
 	}
 
 	gl.CreateVertexArrays(1, &prog.vertexArrayID)
@@ -154,60 +162,94 @@ func buildProgram(shaders ...*shader) *Program {
 		prog.outputColorLocationsByName[out.name] = out.location
 	}
 
-	return &prog
-}
+	var maxUnits int32
+	gl.GetIntegerv(gl.MAX_COMBINED_TEXTURE_IMAGE_UNITS, &maxUnits)
+	if len(prog.textures) > int(maxUnits) {
+		gl.DeleteVertexArrays(1, &prog.vertexArrayID)
+		gl.DeleteProgram(prog.id)
+		return nil, fmt.Errorf("program uses %d texture units, but only %d are available", len(prog.textures), maxUnits)
+	}
 
-func NewProgram(vSrc, fSrc, gSrc string, defines ...string) *Program {
-	shaders := make([]*shader, 0, 3)
+	return &prog, nil
+}
 
-	if vSrc != "" {
-		vShader := newShader(gl.VERTEX_SHADER, vSrc, defines...)
-		shaders = append(shaders, vShader)
-	}
-	if fSrc != "" {
-		fShader := newShader(gl.FRAGMENT_SHADER, fSrc, defines...)
-		shaders = append(shaders, fShader)
-	}
-	if gSrc != "" {
-		gShader := newShader(gl.GEOMETRY_SHADER, gSrc, defines...)
-		shaders = append(shaders, gShader)
+// compile compiles the shaders and links them into the program
+func (prog *Program) compile(types []uint32, srcs []*shaderSource) error {
+	for i, src := range srcs {
+		sh, err := newShader(types[i], src)
+		if err != nil {
+			return err
+		}
+		gl.AttachShader(prog.id, sh.id)
+		defer gl.DetachShader(prog.id, sh.id)
 	}
 
-	return buildProgram(shaders...)
+	gl.ProgramParameteri(prog.id, gl.PROGRAM_BINARY_RETRIEVABLE_HINT, gl.TRUE)
+	return prog.link()
+}
+
+func NewProgram(vSrc, fSrc, gSrc string, defines ...string) *Program {
+	srcs := []string{vSrc, fSrc, gSrc}
+	prog, err := newProgram(nil, []string{"", "", ""}, srcs, defines)
+	if err != nil {
+		panic(err)
+	}
+	return prog
 }
 
 func ReadProgram(vFile, fFile, gFile string, defines ...string) *Program {
-	var vSrc, fSrc, gSrc string
+	return ReadProgramFS(osFS{}, vFile, fFile, gFile, defines...)
+}
 
-	if vFile == "" {
-		vSrc = ""
-	} else {
-		src, err := ioutil.ReadFile(vFile)
-		if err != nil {
-			panic(err)
+// ReadProgramFS reads shader sources from fsys, resolving #include directives
+// relative to the including file
+func ReadProgramFS(fsys fs.FS, vFile, fFile, gFile string, defines ...string) *Program {
+	files := []string{vFile, fFile, gFile}
+	srcs := make([]string, 3)
+	for i, file := range files {
+		if file == "" {
+			continue
 		}
-		vSrc = string(src)
-	}
-	if fFile == "" {
-		fSrc = ""
-	} else {
-		src, err := ioutil.ReadFile(fFile)
+		src, err := fs.ReadFile(fsys, file)
 		if err != nil {
 			panic(err)
 		}
-		fSrc = string(src)
+		srcs[i] = string(src)
 	}
-	if gFile == "" {
-		gSrc = ""
-	} else {
-		src, err := ioutil.ReadFile(gFile)
+	prog, err := newProgram(fsys, files, srcs, defines)
+	if err != nil {
+		panic(err)
+	}
+	return prog
+}
+
+// newProgram preprocesses, compiles and links vertex, fragment and geometry
+// shader sources, skipping empty ones
+func newProgram(fsys fs.FS, files, srcs, defines []string) (*Program, error) {
+	allTypes := []uint32{gl.VERTEX_SHADER, gl.FRAGMENT_SHADER, gl.GEOMETRY_SHADER}
+
+	types := make([]uint32, 0, 3)
+	pps := make([]*shaderSource, 0, 3)
+	for i, src := range srcs {
+		if src == "" {
+			continue
+		}
+		pp, err := preprocessShader(fsys, files[i], src, defines)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
-		gSrc = string(src)
+		types = append(types, allTypes[i])
+		pps = append(pps, pp)
 	}
 
-	return NewProgram(vSrc, fSrc, gSrc, defines...)
+	return buildProgram(types, pps)
+}
+
+// osFS opens files relative to the working directory, like ioutil.ReadFile
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(name)
 }
 
 func (prog *Program) linked() bool {
@@ -233,9 +275,19 @@ func (prog *Program) link() error {
 	return errors.New(prog.log())
 }
 
+// Render draws with the program, or skips the draw and logs an error
+// if its outputs have different sizes
 func (prog *Program) Render(vertexCount int, opts *RenderOptions) {
+	resized, err := prog.framebuffer.validate()
+	if err != nil {
+		log.Print("skipping draw: ", err)
+		return
+	}
 	if currentProg != prog {
 		prog.bind()
+	} else if resized {
+		// the viewport is otherwise only set when switching programs
+		gl.Viewport(0, 0, int32(prog.framebuffer.Width()), int32(prog.framebuffer.Height()))
 	}
 	opts.apply()
 
@@ -246,8 +298,7 @@ func (prog *Program) Render(vertexCount int, opts *RenderOptions) {
 		gl.DrawElements(opts.Primitive.glPrimitive(), int32(vertexCount), gltype, nil)
 	}
 
-	Stats.DrawCallCount++
-	Stats.VertexCount += vertexCount
+	Stats.countDraw(vertexCount, opts.Primitive)
 }
 
 func (prog *Program) bind() {
@@ -255,8 +306,13 @@ func (prog *Program) bind() {
 	gl.BindVertexArray(prog.vertexArrayID)
 	prog.framebuffer.bindDraw()
 	gl.Viewport(0, 0, int32(prog.framebuffer.Width()), int32(prog.framebuffer.Height()))
+	for unit, id := range prog.textures {
+		gl.BindTextureUnit(uint32(unit), id)
+		Stats.countTextureBind()
+	}
 
 	currentProg = prog
+	Stats.countProgramSwitch()
 }
 
 func (prog *Program) SetIndices(b *IndexBuffer) {
@@ -346,9 +402,10 @@ func (prog *Program) uniformByIndex(i, j int) *Uniform {
 	ufm.glType = type_
 
 	// TODO: allow more sampler types
-	if ufm.glType == gl.SAMPLER_2D || ufm.glType == gl.SAMPLER_CUBE {
-		ufm.textureUnitIndex = uint32(textureUnitsUsed)
-		textureUnitsUsed++ // TODO: make texture unit mapping more sophisticated
+	if ufm.glType == gl.SAMPLER_2D || ufm.glType == gl.SAMPLER_CUBE || ufm.glType == gl.SAMPLER_3D {
+		ufm.textureUnitIndex = uint32(len(prog.textures))
+		prog.textures = append(prog.textures, 0)
+		gl.ProgramUniform1i(prog.id, int32(ufm.location), int32(ufm.textureUnitIndex))
 	}
 
 	return &ufm
@@ -370,6 +427,25 @@ func (prog *Program) UniformByName(name string) *Uniform {
 	return prog.UniformByLocation(int(location))
 }
 
+func (prog *Program) StorageBlockByName(name string) *StorageBlock {
+	index := gl.GetProgramResourceIndex(prog.id, gl.SHADER_STORAGE_BLOCK, gl.Str(name+"\x00"))
+	if index == gl.INVALID_INDEX {
+		return nil
+	}
+
+	var blk StorageBlock
+	blk.prog = prog
+	blk.index = index
+	blk.name = name
+
+	// like texture units, give every block its own binding
+	blk.binding = uint32(storageBindingsUsed)
+	storageBindingsUsed++
+	gl.ShaderStorageBlockBinding(prog.id, blk.index, blk.binding)
+
+	return &blk
+}
+
 func (prog *Program) outputColorByIndex(i int) *Output {
 	var out Output
 	out.prog = prog
@@ -441,6 +517,8 @@ func (ufm *Uniform) Set(value interface{}) {
 		return
 	}
 
+	Stats.countUniformUpload()
+
 	switch ufm.glType {
 	case gl.BOOL:
 		value := value.(int32)
@@ -470,20 +548,37 @@ func (ufm *Uniform) Set(value interface{}) {
 		value := value.(*math.Mat4)
 		gl.ProgramUniformMatrix4fv(ufm.prog.id, int32(ufm.location), 1, true, &value[0])
 	case gl.SAMPLER_2D:
-		// TODO: other shaders can mess with this texture index
 		value := value.(*Texture2D)
-		gl.BindTextureUnit(ufm.textureUnitIndex, value.id)
-		gl.ProgramUniform1i(ufm.prog.id, int32(ufm.location), int32(ufm.textureUnitIndex))
+		ufm.setTexture(value.id)
 	case gl.SAMPLER_CUBE:
-		// TODO: other shaders can mess with this texture index
 		value := value.(*CubeMap)
-		gl.BindTextureUnit(ufm.textureUnitIndex, value.id)
-		gl.ProgramUniform1i(ufm.prog.id, int32(ufm.location), int32(ufm.textureUnitIndex))
+		ufm.setTexture(value.id)
+	case gl.SAMPLER_3D:
+		value := value.(*Texture3D)
+		ufm.setTexture(value.id)
 	default:
 		panic("invalid uniform")
 	}
 }
 
-func (out *Output) Set(target renderTarget) {
+// setTexture binds a texture to the uniform's unit now if the program is in use,
+// and otherwise when switching to it, since other programs use the same units
+func (ufm *Uniform) setTexture(id uint32) {
+	ufm.prog.textures[ufm.textureUnitIndex] = id
+	if currentProg == ufm.prog {
+		gl.BindTextureUnit(ufm.textureUnitIndex, id)
+		Stats.countTextureBind()
+	}
+}
+
+func (blk *StorageBlock) Set(buf *StorageBuffer) {
+	if blk == nil {
+		return
+	}
+
+	gl.BindBufferBase(gl.SHADER_STORAGE_BUFFER, blk.binding, buf.id)
+}
+
+func (out *Output) Set(target RenderTarget) {
 	out.prog.framebuffer.attach(target)
 }
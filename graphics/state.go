@@ -48,12 +48,28 @@ const (
 	TriangleOutlineFan
 )
 
-// TODO: enable sorting of these states to reduce state changes?
+type WriteMask int
+
+const (
+	ColorDepthWrite WriteMask = iota
+	ColorWrite
+	DepthWrite
+	NoWrite
+)
+
+// Rect is a rectangle of pixels with origin in the lower left corner
+type Rect struct {
+	X, Y          int
+	Width, Height int
+}
+
 type RenderOptions struct {
 	DepthTest DepthTest
 	Blending  Blending
 	Culling   Culling
 	Primitive Primitive
+	WriteMask WriteMask
+	Scissor   *Rect // nil to render everywhere
 }
 
 var currentOpts RenderOptions
@@ -134,6 +150,49 @@ func (opts *RenderOptions) apply() {
 		}
 		currentOpts.Primitive = opts.Primitive
 	}
+
+	if currentOpts.WriteMask != opts.WriteMask {
+		switch opts.WriteMask {
+		case ColorDepthWrite:
+			gl.ColorMask(true, true, true, true)
+			gl.DepthMask(true)
+		case ColorWrite:
+			gl.ColorMask(true, true, true, true)
+			gl.DepthMask(false)
+		case DepthWrite:
+			gl.ColorMask(false, false, false, false)
+			gl.DepthMask(true)
+		case NoWrite:
+			gl.ColorMask(false, false, false, false)
+			gl.DepthMask(false)
+		default:
+			panic("tried to apply a render state with an unknown write mask")
+		}
+		currentOpts.WriteMask = opts.WriteMask
+	}
+
+	if opts.Scissor == nil {
+		if currentOpts.Scissor != nil {
+			gl.Disable(gl.SCISSOR_TEST)
+			currentOpts.Scissor = nil
+		}
+	} else if currentOpts.Scissor == nil || *currentOpts.Scissor != *opts.Scissor {
+		if currentOpts.Scissor == nil {
+			gl.Enable(gl.SCISSOR_TEST)
+		}
+		rect := *opts.Scissor
+		gl.Scissor(int32(rect.X), int32(rect.Y), int32(rect.Width), int32(rect.Height))
+		currentOpts.Scissor = &rect
+	}
+}
+
+// unmaskWrites restores the write mask and scissor,
+// since clearing and blitting obey them
+func unmaskWrites() {
+	opts := currentOpts
+	opts.WriteMask = ColorDepthWrite
+	opts.Scissor = nil
+	opts.apply()
 }
 
 func (p Primitive) glPrimitive() uint32 {
@@ -1,23 +1,258 @@
 package graphics
 
 import (
+	"encoding/csv"
 	"fmt"
+	"io"
+	"strconv"
 )
 
+// Counters count the rendering work done in a frame or a pass
+type Counters struct {
+	DrawCallCount      int
+	VertexCount        int
+	TriangleCount      int
+	TextureBindCount   int
+	ProgramSwitchCount int
+	UniformUploadCount int
+	DrawnSubMeshCount  int
+	CulledSubMeshCount int
+	ShadowMapCount     int
+
+	LightCulledSubMeshCount int // outside the volume of a light
+	CulledLightCount        int // outside the screen
+}
+
+// Statistics counts the rendering work in the current frame, both in total
+// and for each pass, and keeps the counts of past frames
 type Statistics struct {
-	DrawCallCount   int
-	VertexCount     int
+	Counters // totals of the current frame
+
+	HistoryLength int
+	history       []FrameStatistics
+
+	passes  map[string]*Counters
+	order   []string
+	current *Counters // counters of the current pass
+	other   Counters  // work done outside any pass
+}
+
+// FrameStatistics are the counts of one finished frame
+type FrameStatistics struct {
+	Total  Counters
+	Passes []PassStatistics
 }
 
-var Stats *Statistics = &Statistics{}
+type PassStatistics struct {
+	Name string
+	Counters
+}
+
+var Stats *Statistics = NewStatistics()
+
+var counterNames = []string{
+	"draws", "vertices", "triangles", "texturebinds", "programswitches",
+	"uniformuploads", "drawnsubmeshes", "culledsubmeshes", "shadowmaps",
+	"lightculledsubmeshes", "culledlights",
+}
+
+func NewStatistics() *Statistics {
+	var stats Statistics
+	stats.HistoryLength = 300
+	stats.passes = make(map[string]*Counters)
+	stats.current = &stats.other
+	return &stats
+}
+
+func (c *Counters) values() []*int {
+	return []*int{
+		&c.DrawCallCount, &c.VertexCount, &c.TriangleCount,
+		&c.TextureBindCount, &c.ProgramSwitchCount, &c.UniformUploadCount,
+		&c.DrawnSubMeshCount, &c.CulledSubMeshCount, &c.ShadowMapCount,
+		&c.LightCulledSubMeshCount, &c.CulledLightCount,
+	}
+}
+
+func (c *Counters) String() string {
+	text := fmt.Sprint(c.DrawCallCount) + " draw calls, "
+	text += fmt.Sprint(c.VertexCount) + " vertices"
+	return text
+}
+
+func (stats *Statistics) beginPass(name string) {
+	pass, found := stats.passes[name]
+	if !found {
+		pass = &Counters{}
+		stats.passes[name] = pass
+		stats.order = append(stats.order, name)
+	}
+	stats.current = pass
+}
+
+func (stats *Statistics) endPass() {
+	stats.current = &stats.other
+}
+
+func (stats *Statistics) countDraw(vertexCount int, primitive Primitive) {
+	var triangleCount int
+	switch primitive {
+	case Triangles, TriangleOutlines:
+		triangleCount = vertexCount / 3
+	case TriangleStrip, TriangleFan, TriangleOutlineStrip, TriangleOutlineFan:
+		if vertexCount > 2 {
+			triangleCount = vertexCount - 2
+		}
+	}
+
+	for _, c := range []*Counters{&stats.Counters, stats.current} {
+		c.DrawCallCount++
+		c.VertexCount += vertexCount
+		c.TriangleCount += triangleCount
+	}
+}
+
+func (stats *Statistics) countTextureBind() {
+	stats.Counters.TextureBindCount++
+	stats.current.TextureBindCount++
+}
+
+func (stats *Statistics) countProgramSwitch() {
+	stats.Counters.ProgramSwitchCount++
+	stats.current.ProgramSwitchCount++
+}
+
+func (stats *Statistics) countUniformUpload() {
+	stats.Counters.UniformUploadCount++
+	stats.current.UniformUploadCount++
+}
+
+// CountSubMeshes counts submeshes that were drawn and culled in the current pass
+func (stats *Statistics) CountSubMeshes(drawn, culled int) {
+	stats.Counters.DrawnSubMeshCount += drawn
+	stats.current.DrawnSubMeshCount += drawn
+	stats.Counters.CulledSubMeshCount += culled
+	stats.current.CulledSubMeshCount += culled
+}
+
+// CountLightCulledSubMeshes counts submeshes skipped because a light does not reach them
+func (stats *Statistics) CountLightCulledSubMeshes(culled int) {
+	stats.Counters.LightCulledSubMeshCount += culled
+	stats.current.LightCulledSubMeshCount += culled
+}
+
+func (stats *Statistics) CountCulledLight() {
+	stats.Counters.CulledLightCount++
+	stats.current.CulledLightCount++
+}
+
+func (stats *Statistics) CountShadowMap() {
+	stats.Counters.ShadowMapCount++
+	stats.current.ShadowMapCount++
+}
+
+// EndFrame saves the counts of the current frame to the history and resets them
+func (stats *Statistics) EndFrame() {
+	var frame FrameStatistics
+	frame.Total = stats.Counters
+	for _, name := range stats.order {
+		frame.Passes = append(frame.Passes, PassStatistics{name, *stats.passes[name]})
+	}
+	if stats.other != (Counters{}) {
+		frame.Passes = append(frame.Passes, PassStatistics{"other", stats.other})
+	}
+
+	stats.history = append(stats.history, frame)
+	if len(stats.history) > stats.HistoryLength {
+		stats.history = stats.history[len(stats.history)-stats.HistoryLength:]
+	}
+
+	stats.Reset()
+}
 
 func (stats *Statistics) Reset() {
-	stats.DrawCallCount = 0
-	stats.VertexCount = 0
+	stats.Counters = Counters{}
+	for _, pass := range stats.passes {
+		*pass = Counters{}
+	}
+	stats.other = Counters{}
+}
+
+// withTotal returns the pass counts followed by the totals as a pass
+func (frame FrameStatistics) withTotal() []PassStatistics {
+	passes := make([]PassStatistics, 0, len(frame.Passes)+1)
+	passes = append(passes, frame.Passes...)
+	return append(passes, PassStatistics{"total", frame.Total})
+}
+
+// History returns the counts of past frames, oldest first
+func (stats *Statistics) History() []FrameStatistics {
+	return stats.history
+}
+
+// LastFrame returns the counts of the last finished frame
+func (stats *Statistics) LastFrame() FrameStatistics {
+	if len(stats.history) == 0 {
+		return FrameStatistics{}
+	}
+	return stats.history[len(stats.history)-1]
+}
+
+// Summary returns the minimum, average and maximum of each total count
+// over the frames in the history
+func (stats *Statistics) Summary() (min, avg, max Counters) {
+	if len(stats.history) == 0 {
+		return
+	}
+
+	min = stats.history[0].Total
+	max = stats.history[0].Total
+	mins, avgs, maxs := min.values(), avg.values(), max.values()
+	for _, frame := range stats.history {
+		for i, value := range frame.Total.values() {
+			if *value < *mins[i] {
+				*mins[i] = *value
+			}
+			if *value > *maxs[i] {
+				*maxs[i] = *value
+			}
+			*avgs[i] += *value
+		}
+	}
+	for _, value := range avgs {
+		*value /= len(stats.history)
+	}
+	return
+}
+
+// WriteCSV writes one row with the counts of each pass of each frame in the
+// history, and one with the totals, for comparing benchmark runs
+func (stats *Statistics) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	out.Write(append([]string{"frame", "pass"}, counterNames...))
+
+	for i, frame := range stats.history {
+		for _, pass := range frame.withTotal() {
+			record := []string{strconv.Itoa(i), pass.Name}
+			for _, value := range pass.values() {
+				record = append(record, strconv.Itoa(*value))
+			}
+			out.Write(record)
+		}
+	}
+
+	out.Flush()
+	return out.Error()
 }
 
 func (stats *Statistics) String() string {
-	text := fmt.Sprint(stats.DrawCallCount) + " draw calls, "
-	text += fmt.Sprint(stats.VertexCount) + " vertices"
+	frame := stats.LastFrame()
+	min, avg, max := stats.Summary()
+
+	text := fmt.Sprintf("%-8s %6s %8s %6s %6s %6s %6s %7s", "pass", "draws", "tris", "texs", "progs", "drawn", "culled", "lculled")
+	for _, pass := range frame.withTotal() {
+		c := pass.Counters
+		text += fmt.Sprintf("\n%-8s %6d %8d %6d %6d %6d %6d %7d", pass.Name, c.DrawCallCount, c.TriangleCount, c.TextureBindCount, c.ProgramSwitchCount, c.DrawnSubMeshCount, c.CulledSubMeshCount, c.LightCulledSubMeshCount)
+	}
+	text += fmt.Sprintf("\ndraws min/avg/max %d/%d/%d", min.DrawCallCount, avg.DrawCallCount, max.DrawCallCount)
 	return text
 }
@@ -0,0 +1,108 @@
+package graphics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newTestStatistics returns statistics with two finished frames,
+// where the first has a shadow pass and work outside any pass
+func newTestStatistics() *Statistics {
+	stats := NewStatistics()
+
+	stats.beginPass("shadow")
+	stats.countDraw(6, Triangles)
+	stats.endPass()
+	stats.countTextureBind()
+	stats.EndFrame()
+
+	stats.beginPass("shadow")
+	stats.countDraw(12, Triangles)
+	stats.countDraw(5, TriangleStrip)
+	stats.endPass()
+	stats.EndFrame()
+
+	return stats
+}
+
+func TestStatisticsWriteCSV(t *testing.T) {
+	stats := newTestStatistics()
+
+	var buf bytes.Buffer
+	err := stats.WriteCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := "frame,pass,draws,vertices,triangles,texturebinds,programswitches,uniformuploads,drawnsubmeshes,culledsubmeshes,shadowmaps,lightculledsubmeshes,culledlights"
+	expected := []string{
+		header,
+		"0,shadow,1,6,2,0,0,0,0,0,0,0,0",
+		"0,other,0,0,0,1,0,0,0,0,0,0,0",
+		"0,total,1,6,2,1,0,0,0,0,0,0,0",
+		"1,shadow,2,17,7,0,0,0,0,0,0,0,0",
+		"1,total,2,17,7,0,0,0,0,0,0,0,0",
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("got %d rows, expected %d", len(records), len(expected))
+	}
+	for i, record := range records {
+		if len(record) != len(counterNames)+2 {
+			t.Errorf("row %d has %d columns, expected %d", i, len(record), len(counterNames)+2)
+		}
+		row := strings.Join(record, ",")
+		if row != expected[i] {
+			t.Errorf("row %d is %q, expected %q", i, row, expected[i])
+		}
+	}
+}
+
+func TestStatisticsSummary(t *testing.T) {
+	stats := newTestStatistics()
+
+	min, avg, max := stats.Summary()
+	if min.DrawCallCount != 1 || avg.DrawCallCount != 1 || max.DrawCallCount != 2 {
+		t.Errorf("got draws %d/%d/%d, expected 1/1/2", min.DrawCallCount, avg.DrawCallCount, max.DrawCallCount)
+	}
+	if min.VertexCount != 6 || avg.VertexCount != 11 || max.VertexCount != 17 {
+		t.Errorf("got vertices %d/%d/%d, expected 6/11/17", min.VertexCount, avg.VertexCount, max.VertexCount)
+	}
+	if min.TextureBindCount != 0 || max.TextureBindCount != 1 {
+		t.Errorf("got texture binds %d/%d, expected 0/1", min.TextureBindCount, max.TextureBindCount)
+	}
+
+	// the summary must not change the history
+	if stats.History()[0].Total.VertexCount != 6 {
+		t.Errorf("summary modified the history")
+	}
+}
+
+func TestStatisticsHistoryLength(t *testing.T) {
+	stats := NewStatistics()
+	stats.HistoryLength = 2
+	for i := 1; i <= 3; i++ {
+		stats.countDraw(i, Points)
+		stats.EndFrame()
+	}
+
+	var vertices []int
+	for _, frame := range stats.History() {
+		vertices = append(vertices, frame.Total.VertexCount)
+	}
+	if !reflect.DeepEqual(vertices, []int{2, 3}) {
+		t.Errorf("got vertex counts %v, expected [2 3]", vertices)
+	}
+
+	min, avg, max := NewStatistics().Summary()
+	if min != (Counters{}) || avg != (Counters{}) || max != (Counters{}) {
+		t.Errorf("expected zero summary without history")
+	}
+}
@@ -30,6 +30,28 @@ type CubeMap struct {
 	levels int
 }
 
+// Texture2DMultisample stores several samples per pixel for antialiasing,
+// and must be resolved to a Texture2D before it is sampled
+type Texture2DMultisample struct {
+	id      uint32
+	width   int
+	height  int
+	type_   TextureType
+	samples int
+
+	framebuffer         *framebuffer
+	resolveFramebuffers map[*Texture2D]*framebuffer
+}
+
+// Texture3D is a color volume, e.g. a color lookup table
+type Texture3D struct {
+	id     uint32
+	width  int
+	height int
+	depth  int
+	components int
+}
+
 type cubeMapFace struct {
 	*CubeMap
 	layer CubeMapLayer
@@ -74,6 +96,17 @@ func colorTextureInternalFormat(floating bool, bits, components int) uint32 {
 	switch floating {
 	case true:
 		switch bits {
+		case 16:
+			switch components {
+			case 1:
+				return gl.R16F
+			case 2:
+				return gl.RG16F
+			case 3:
+				return gl.RGB16F
+			case 4:
+				return gl.RGBA16F
+			}
 		case 32:
 			switch components {
 			case 1:
@@ -192,6 +225,11 @@ func NewUniformTexture2D(rgba math.Vec4) *Texture2D {
 	return LoadTexture2D(ColorTexture, NearestFilter, EdgeClampWrap, img, false)
 }
 
+// Delete frees the texture, which must not be used afterwards
+func (tex *Texture2D) Delete() {
+	gl.DeleteTextures(1, &tex.id)
+}
+
 func (tex *Texture2D) Width() int {
 	return tex.width
 }
@@ -277,6 +315,12 @@ func (tex *Texture2D) SetData(x0, y0, w, h int, data interface{}) {
 	gl.PixelStorei(gl.UNPACK_ALIGNMENT, 1)
 	p := unsafe.Pointer(&byteSlice(data)[0])
 	gl.TextureSubImage2D(tex.id, 0, int32(x0), int32(y0), int32(w), int32(h), pixelFormat, pixelDataType, p)
+	tex.GenerateMipmap()
+}
+
+// GenerateMipmap updates the smaller levels from the largest one,
+// e.g. after rendering to it
+func (tex *Texture2D) GenerateMipmap() {
 	mipmap := tex.levels > 1
 	if mipmap {
 		gl.GenerateTextureMipmap(tex.id)
@@ -287,7 +331,7 @@ func (tex *Texture2D) Display(blend Blending) {
 	displayTexture(tex, blend)
 }
 
-func (tex *Texture2D) attachTo(f *framebuffer) {
+func (tex *Texture2D) attachTo(f *framebuffer) uint32 {
 	var glatt uint32
 	switch tex.type_ {
 	case ColorTexture:
@@ -300,6 +344,7 @@ func (tex *Texture2D) attachTo(f *framebuffer) {
 		panic("invalid texture format")
 	}
 	gl.NamedFramebufferTexture(f.id, glatt, tex.id, 0)
+	return glatt
 }
 
 func (tex *Texture2D) glFormat() uint32 {
@@ -315,6 +360,93 @@ func (tex *Texture2D) glFormat() uint32 {
 	}
 }
 
+func newTexture2DMultisample(type_ TextureType, format uint32, width, height, samples int) *Texture2DMultisample {
+	var tex Texture2DMultisample
+	tex.width = width
+	tex.height = height
+	tex.type_ = type_
+	tex.samples = samples
+	gl.CreateTextures(gl.TEXTURE_2D_MULTISAMPLE, 1, &tex.id)
+	gl.TextureStorage2DMultisample(tex.id, int32(samples), format, int32(width), int32(height), true)
+
+	tex.framebuffer = newFramebuffer()
+	tex.framebuffer.attach(&tex)
+	tex.resolveFramebuffers = make(map[*Texture2D]*framebuffer)
+	return &tex
+}
+
+func NewColorTexture2DMultisample(width, height, samples int, components int, bits int, floating bool) *Texture2DMultisample {
+	format := colorTextureInternalFormat(floating, bits, components)
+	return newTexture2DMultisample(ColorTexture, format, width, height, samples)
+}
+
+func NewDepthTexture2DMultisample(width, height, samples int) *Texture2DMultisample {
+	return newTexture2DMultisample(DepthTexture, gl.DEPTH_COMPONENT16, width, height, samples)
+}
+
+func (tex *Texture2DMultisample) Width() int {
+	return tex.width
+}
+
+func (tex *Texture2DMultisample) Height() int {
+	return tex.height
+}
+
+func (tex *Texture2DMultisample) Samples() int {
+	return tex.samples
+}
+
+func (tex *Texture2DMultisample) Clear(rgba math.Vec4) {
+	var format uint32
+	switch tex.type_ {
+	case ColorTexture:
+		format = gl.RGBA
+	case DepthTexture:
+		format = gl.DEPTH_COMPONENT
+	default:
+		panic("invalid texture type")
+	}
+	gl.ClearTexImage(tex.id, 0, format, gl.FLOAT, unsafe.Pointer(&rgba[0]))
+}
+
+// Resolve combines the samples of every pixel into a texture of the same size and type
+func (tex *Texture2DMultisample) Resolve(dst *Texture2D) {
+	fb, found := tex.resolveFramebuffers[dst]
+	if !found {
+		fb = newFramebuffer()
+		fb.attach(dst)
+		tex.resolveFramebuffers[dst] = fb
+	}
+
+	var mask uint32
+	switch tex.type_ {
+	case ColorTexture:
+		mask = gl.COLOR_BUFFER_BIT
+	case DepthTexture:
+		mask = gl.DEPTH_BUFFER_BIT
+	default:
+		panic("invalid texture type")
+	}
+
+	unmaskWrites()
+	w, h := int32(tex.width), int32(tex.height)
+	gl.BlitNamedFramebuffer(tex.framebuffer.id, fb.id, 0, 0, w, h, 0, 0, w, h, mask, gl.NEAREST)
+}
+
+func (tex *Texture2DMultisample) attachTo(f *framebuffer) uint32 {
+	var glatt uint32
+	switch tex.type_ {
+	case ColorTexture:
+		glatt = gl.COLOR_ATTACHMENT0
+	case DepthTexture:
+		glatt = gl.DEPTH_ATTACHMENT
+	default:
+		panic("invalid texture format")
+	}
+	gl.NamedFramebufferTexture(f.id, glatt, tex.id, 0)
+	return glatt
+}
+
 func NewCubeMap(type_ TextureType, filter TextureFilter, width, height int) *CubeMap {
 	var cube CubeMap
 	cube.width = width
@@ -397,7 +529,7 @@ func (cube *CubeMap) Face(layer CubeMapLayer) *cubeMapFace {
 	return &face
 }
 
-func (cube *CubeMap) attachTo(f *framebuffer) {
+func (cube *CubeMap) attachTo(f *framebuffer) uint32 {
 	var glatt uint32
 	switch cube.type_ {
 	case ColorTexture:
@@ -410,6 +542,7 @@ func (cube *CubeMap) attachTo(f *framebuffer) {
 		panic("invalid texture format")
 	}
 	gl.NamedFramebufferTexture(f.id, glatt, cube.id, 0)
+	return glatt
 }
 
 func (cube *CubeMap) glFormat() uint32 {
@@ -431,7 +564,7 @@ func (face *cubeMapFace) Height() int {
 	return face.CubeMap.height
 }
 
-func (face *cubeMapFace) attachTo(f *framebuffer) {
+func (face *cubeMapFace) attachTo(f *framebuffer) uint32 {
 	var glatt uint32
 	switch face.CubeMap.type_ {
 	case ColorTexture:
@@ -444,4 +577,64 @@ func (face *cubeMapFace) attachTo(f *framebuffer) {
 		panic("invalid texture format")
 	}
 	gl.NamedFramebufferTextureLayer(f.id, glatt, face.CubeMap.id, 0, int32(face.layer))
+	return glatt
+}
+
+func NewColorTexture3D(filter TextureFilter, wrap TextureWrap, width, height, depth int, components int, bits int, floating bool) *Texture3D {
+	var tex Texture3D
+	tex.width = width
+	tex.height = height
+	tex.depth = depth
+	tex.components = components
+	gl.CreateTextures(gl.TEXTURE_3D, 1, &tex.id)
+
+	gl.TextureParameteri(tex.id, gl.TEXTURE_WRAP_S, int32(wrap))
+	gl.TextureParameteri(tex.id, gl.TEXTURE_WRAP_T, int32(wrap))
+	gl.TextureParameteri(tex.id, gl.TEXTURE_WRAP_R, int32(wrap))
+	gl.TextureParameteri(tex.id, gl.TEXTURE_MIN_FILTER, int32(filter))
+	gl.TextureParameteri(tex.id, gl.TEXTURE_MAG_FILTER, int32(filter))
+
+	glType := colorTextureInternalFormat(floating, bits, components)
+
+	gl.TextureStorage3D(tex.id, 1, glType, int32(width), int32(height), int32(depth))
+	return &tex
+}
+
+// Delete frees the texture, which must not be used afterwards
+func (tex *Texture3D) Delete() {
+	gl.DeleteTextures(1, &tex.id)
+}
+
+func (tex *Texture3D) Width() int {
+	return tex.width
+}
+
+func (tex *Texture3D) Height() int {
+	return tex.height
+}
+
+func (tex *Texture3D) Depth() int {
+	return tex.depth
+}
+
+// SetData fills the whole texture with float data, with x varying fastest
+// and z slowest
+func (tex *Texture3D) SetData(data interface{}) {
+	var pixelFormat uint32
+	switch tex.components {
+	case 1:
+		pixelFormat = gl.RED
+	case 2:
+		pixelFormat = gl.RG
+	case 3:
+		pixelFormat = gl.RGB
+	case 4:
+		pixelFormat = gl.RGBA
+	default:
+		panic("invalid component count")
+	}
+
+	gl.PixelStorei(gl.UNPACK_ALIGNMENT, 1)
+	p := unsafe.Pointer(&byteSlice(data)[0])
+	gl.TextureSubImage3D(tex.id, 0, 0, 0, 0, int32(tex.width), int32(tex.height), int32(tex.depth), pixelFormat, gl.FLOAT, p)
 }
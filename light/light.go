@@ -4,6 +4,7 @@ import (
 	"github.com/hersle/gl3d/camera"
 	"github.com/hersle/gl3d/math"
 	"github.com/hersle/gl3d/object"
+	"image"
 	gomath "math"
 )
 
@@ -12,22 +13,56 @@ type AmbientLight struct {
 	Intensity float32
 }
 
+// Attenuation weakens a light at distance d by the factor
+// 1 / (AttenuationConstant + AttenuationLinear*d + AttenuationQuadratic*d^2),
+// smoothly cut off to 0 at InfluenceRange. Distances are clamped to
+// at least 0.1 when shading, like from the surface of a small bulb.
+type Attenuation struct {
+	AttenuationConstant  float32
+	AttenuationLinear    float32
+	AttenuationQuadratic float32
+	Range                float32
+}
+
 type PointLight struct {
 	object.Object
+	Attenuation
 	Color                math.Vec3
 	Intensity            float32
-	ShadowFar            float32
-	Attenuation          float32
+	ShadowFar            float32 // 0 means the influence range
 	CastShadows          bool
 }
 
 type SpotLight struct {
 	camera.PerspectiveCamera
+	Attenuation
 	Color                math.Vec3
 	Intensity            float32
-	Attenuation          float32
+	ShadowFar            float32 // 0 means the influence range
 	CastShadows          bool
-	FOV                  float32
+	FOV                  float32 // of the outer cone, beyond which nothing is lit
+	InnerFOV             float32 // of the inner cone, which is lit at full intensity (at most FOV)
+	Cookie               image.Image // projected over the outer cone to modulate the color, or nil
+}
+
+type AreaShape int
+
+const (
+	RectangleShape AreaShape = iota
+	DiskShape
+)
+
+// AreaLight is a flat rectangle or ellipse that emits light along its UnitZ
+// axis, with sides along UnitX and UnitY
+type AreaLight struct {
+	object.Object
+	Shape     AreaShape
+	Color     math.Vec3
+	Intensity float32
+	Width     float32
+	Height    float32
+	Range     float32 // distance from the center where the light is smoothly cut off, or 0 for no limit
+	TwoSided  bool // emit from both sides
 }
 
 type DirectionalLight struct {
@@ -49,8 +84,7 @@ func NewPointLight(color math.Vec3) *PointLight {
 	l.Object = *object.NewObject()
 	l.Color = color
 	l.Intensity = 1
-	l.ShadowFar = 50
-	l.Attenuation = 0
+	l.Attenuation = *NewAttenuation()
 	l.CastShadows = false
 	return &l
 }
@@ -59,14 +93,24 @@ func (l *PointLight) Place(position math.Vec3) {
 	l.Object.Place(position)
 }
 
+// ShadowDistance returns the distance shadows are cast to
+func (l *PointLight) ShadowDistance() float32 {
+	return shadowDistance(l.ShadowFar, l.InfluenceRange())
+}
+
+func (l *PointLight) BoundingSphere() *object.Sphere {
+	return object.NewSphere(l.Position, l.InfluenceRange())
+}
+
 func NewSpotLight(color math.Vec3) *SpotLight {
 	var l SpotLight
 	l.Color = color
 	l.Intensity = 1
-	l.PerspectiveCamera = *camera.NewPerspectiveCamera(90, 1, 0.1, 50)
-	l.Attenuation = 0
+	l.PerspectiveCamera = *camera.NewPerspectiveCamera(90, 1, 0.1, defaultShadowFar)
+	l.Attenuation = *NewAttenuation()
 	l.CastShadows = false
 	l.FOV = gomath.Pi / 2
+	l.InnerFOV = l.FOV // a hard edge, unless set narrower
 	return &l
 }
 
@@ -78,6 +122,109 @@ func (l *SpotLight) Orient(unitX, unitY math.Vec3) {
 	l.Object.Orient(unitX, unitY)
 }
 
+// ConeCosines returns the cosines of the half angles of the inner and outer
+// cones, with the inner cone no wider than the outer
+func (l *SpotLight) ConeCosines() (inner, outer float32) {
+	inner = float32(gomath.Cos(float64(l.InnerFOV / 2)))
+	outer = float32(gomath.Cos(float64(l.FOV / 2)))
+	if inner < outer {
+		inner = outer
+	}
+	return inner, outer
+}
+
+// ShadowDistance returns the distance shadows are cast to, which is also
+// the far plane of the light's camera when its shadow map is rendered
+func (l *SpotLight) ShadowDistance() float32 {
+	return shadowDistance(l.ShadowFar, l.InfluenceRange())
+}
+
+// BoundingCone returns a cone containing the lit region. If the light is not
+// attenuated, the cone is one unit high and contains the region when it is
+// extended infinitely beyond its base.
+func (l *SpotLight) BoundingCone() *object.Cone {
+	height := l.InfluenceRange()
+	if gomath.IsInf(float64(height), +1) {
+		height = 1
+	}
+	radius := height * float32(gomath.Tan(float64(l.FOV/2)))
+	base := l.Position.Add(l.Forward().Scale(height))
+	return object.NewCone(base, l.Position, radius)
+}
+
+// BoundingSphere returns the tightest of the spheres around the position and
+// around the bounding cone
+func (l *SpotLight) BoundingSphere() *object.Sphere {
+	sphere := object.NewSphere(l.Position, l.InfluenceRange())
+	if gomath.IsInf(float64(sphere.Radius), +1) {
+		return sphere
+	}
+	coneSphere := l.BoundingCone().BoundingSphere()
+	if coneSphere.Radius < sphere.Radius {
+		return coneSphere
+	}
+	return sphere
+}
+
+func NewRectangleLight(color math.Vec3, width, height float32) *AreaLight {
+	var l AreaLight
+	l.Object = *object.NewObject()
+	l.Shape = RectangleShape
+	l.Color = color
+	l.Intensity = 1
+	l.Width = width
+	l.Height = height
+	return &l
+}
+
+func NewDiskLight(color math.Vec3, radius float32) *AreaLight {
+	l := NewRectangleLight(color, 2*radius, 2*radius)
+	l.Shape = DiskShape
+	return l
+}
+
+// Normal returns the direction the light is emitted in
+func (l *AreaLight) Normal() math.Vec3 {
+	return l.UnitZ
+}
+
+// BoundingSphere returns a sphere around all the light reaches, of which a
+// one-sided light only lights the half in front of it
+func (l *AreaLight) BoundingSphere() *object.Sphere {
+	if l.Range > 0 {
+		return object.NewSphere(l.Position, l.Range)
+	}
+	return object.NewSphere(l.Position, float32(gomath.Inf(+1)))
+}
+
+// Outline returns world space points around the edge of the light,
+// clockwise as seen from the front. A disk is approximated by n points.
+func (l *AreaLight) Outline(n int) []math.Vec3 {
+	halfX := l.UnitX.Scale(l.Width / 2)
+	halfY := l.UnitY.Scale(l.Height / 2)
+
+	switch l.Shape {
+	case RectangleShape:
+		return []math.Vec3{
+			l.Position.Add(halfX).Add(halfY),
+			l.Position.Add(halfX).Sub(halfY),
+			l.Position.Sub(halfX).Sub(halfY),
+			l.Position.Sub(halfX).Add(halfY),
+		}
+	case DiskShape:
+		points := make([]math.Vec3, n)
+		for i := range points {
+			ang := -2 * gomath.Pi * float64(i) / float64(n)
+			cos := float32(gomath.Cos(ang))
+			sin := float32(gomath.Sin(ang))
+			points[i] = l.Position.Add(halfX.Scale(cos)).Add(halfY.Scale(sin))
+		}
+		return points
+	default:
+		panic("invalid area light shape")
+	}
+}
+
 func NewDirectionalLight(color math.Vec3) *DirectionalLight {
 	var l DirectionalLight
 	l.Color = color
@@ -95,3 +242,62 @@ func (l *DirectionalLight) Place(position math.Vec3) {
 func (l *DirectionalLight) Orient(unitX, unitY math.Vec3) {
 	l.Object.Orient(unitX, unitY)
 }
+
+// NewAttenuation returns no attenuation
+func NewAttenuation() *Attenuation {
+	var a Attenuation
+	a.AttenuationConstant = 1
+	return &a
+}
+
+// SetInverseSquare makes the attenuation physically based, falling off with
+// the square of the distance until it is cut off at rng
+func (a *Attenuation) SetInverseSquare(rng float32) {
+	a.AttenuationConstant = 0
+	a.AttenuationLinear = 0
+	a.AttenuationQuadratic = 1
+	a.Range = rng
+}
+
+// smallest influence range, so lights that are dim everywhere still light
+// their immediate surroundings and cast shadows
+const minInfluenceRange = 0.2
+
+// InfluenceRange returns Range if it is set, or else the distance at which
+// the light is attenuated to 5%, but at least minInfluenceRange
+func (a *Attenuation) InfluenceRange() float32 {
+	if a.Range > 0 {
+		return a.Range
+	}
+
+	// solve c + l*d + q*d^2 = 1/0.05
+	c := float64(a.AttenuationConstant - 1/0.05)
+	l := float64(a.AttenuationLinear)
+	q := float64(a.AttenuationQuadratic)
+	switch {
+	case c >= 0:
+		return minInfluenceRange
+	case q > 0:
+		return math.Max(float32((-l+gomath.Sqrt(l*l-4*q*c))/(2*q)), minInfluenceRange)
+	case l > 0:
+		return math.Max(float32(-c/l), minInfluenceRange)
+	default:
+		return float32(gomath.Inf(+1))
+	}
+}
+
+// used for shadows of lights that reach infinitely far
+const defaultShadowFar = 50
+
+// beyond the near plane at 0.1 that shadow maps are rendered with
+const minShadowFar = 0.2
+
+func shadowDistance(shadowFar, influenceRange float32) float32 {
+	if shadowFar > 0 {
+		return math.Max(shadowFar, minShadowFar)
+	}
+	if gomath.IsInf(float64(influenceRange), +1) {
+		return defaultShadowFar
+	}
+	return math.Max(influenceRange, minShadowFar)
+}
@@ -0,0 +1,135 @@
+package light
+
+import (
+	"github.com/hersle/gl3d/math"
+	gomath "math"
+	"testing"
+)
+
+func TestInfluenceRange(t *testing.T) {
+	tests := []struct {
+		constant, linear, quadratic float32
+		rng                         float32
+		expected                    float32
+	}{
+		{1, 0, 0, 0, float32(gomath.Inf(+1))}, // never attenuated
+		{1, 0, 0, 15, 15},                     // range overrides
+		{0, 0, 1, 7, 7},
+		{0, 0, 1, 0, float32(gomath.Sqrt(20))}, // 1/d^2 = 0.05
+		{0, 2, 0, 0, 10},                       // 1/(2d) = 0.05
+		{1, 1, 0, 0, 19},                       // 1/(1+d) = 0.05
+		{1, 0, 0.19, 0, 10},                    // 1/(1+0.19d^2) = 0.05
+		{20, 1, 1, 0, minInfluenceRange},       // dimmer than 5% everywhere
+		{30, 0, 0, 0, minInfluenceRange},
+		{1, 1000, 0, 0, minInfluenceRange},     // dimmer than 5% very close
+	}
+	for _, test := range tests {
+		a := Attenuation{test.constant, test.linear, test.quadratic, test.rng}
+		got := a.InfluenceRange()
+		if !approxEqual(got, test.expected) && got != test.expected {
+			t.Errorf("%+v: got influence range %f, expected %f", a, got, test.expected)
+		}
+	}
+}
+
+func TestSetInverseSquare(t *testing.T) {
+	a := NewAttenuation()
+	if !gomath.IsInf(float64(a.InfluenceRange()), +1) {
+		t.Errorf("got influence range %f without attenuation, expected infinity", a.InfluenceRange())
+	}
+
+	a.SetInverseSquare(12)
+	if a.AttenuationConstant != 0 || a.AttenuationLinear != 0 || a.AttenuationQuadratic != 1 {
+		t.Errorf("got terms %+v, expected only a quadratic one", a)
+	}
+	if a.InfluenceRange() != 12 {
+		t.Errorf("got influence range %f, expected 12", a.InfluenceRange())
+	}
+
+	if shadowDistance(0, a.InfluenceRange()) != 12 || shadowDistance(5, a.InfluenceRange()) != 5 {
+		t.Errorf("shadows must reach the influence range unless ShadowFar is set")
+	}
+	if shadowDistance(0.05, 12) != minShadowFar || shadowDistance(0, 0.05) != minShadowFar {
+		t.Errorf("shadows must reach beyond the near plane")
+	}
+	a.SetInverseSquare(0)
+	a.AttenuationConstant = 20
+	if d := shadowDistance(0, a.InfluenceRange()); d <= 0.1 {
+		t.Errorf("got shadow distance %f for a dim light, expected one beyond the near plane", d)
+	}
+	if shadowDistance(0, float32(gomath.Inf(+1))) != defaultShadowFar {
+		t.Errorf("shadows of infinitely reaching lights must use the default distance")
+	}
+}
+
+// clockwise tests if points wind clockwise as seen from the front of normal
+func clockwise(points []math.Vec3, normal math.Vec3) bool {
+	for i := range points {
+		a := points[i]
+		b := points[(i+1)%len(points)]
+		c := points[(i+2)%len(points)]
+		if b.Sub(a).Cross(c.Sub(b)).Dot(normal) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAreaLightOutline(t *testing.T) {
+	l := NewRectangleLight(math.Vec3{1, 1, 1}, 4, 2)
+	l.Place(math.Vec3{1, 0, 0})
+	l.Orient(math.Vec3{0, 0, -1}, math.Vec3{0, 1, 0}) // facing +x
+
+	// n is ignored for rectangles
+	points := l.Outline(16)
+	expected := []math.Vec3{
+		{1, 1, -2},
+		{1, -1, -2},
+		{1, -1, 2},
+		{1, 1, 2},
+	}
+	if len(points) != len(expected) {
+		t.Fatalf("got %d points, expected %d", len(points), len(expected))
+	}
+	for i := range points {
+		if points[i].Sub(expected[i]).Length() > 1e-6 {
+			t.Errorf("point %d is %v, expected %v", i, points[i], expected[i])
+		}
+	}
+	if !clockwise(points, l.Normal()) {
+		t.Errorf("rectangle %v is not clockwise seen from the front", points)
+	}
+
+	l = NewDiskLight(math.Vec3{1, 1, 1}, 2)
+	l.Orient(math.Vec3{0, 0, -1}, math.Vec3{0, 1, 0})
+	points = l.Outline(8)
+	if len(points) != 8 {
+		t.Fatalf("got %d points, expected 8", len(points))
+	}
+	for i, point := range points {
+		if !approxEqual(point.Length(), 2) {
+			t.Errorf("point %d is %v, expected it at radius 2", i, point)
+		}
+	}
+	if !clockwise(points, l.Normal()) {
+		t.Errorf("disk %v is not clockwise seen from the front", points)
+	}
+}
+
+func TestAreaLightBoundingSphere(t *testing.T) {
+	l := NewRectangleLight(math.Vec3{1, 1, 1}, 1, 1)
+	l.Place(math.Vec3{0, 2, 0})
+	if !gomath.IsInf(float64(l.BoundingSphere().Radius), +1) {
+		t.Errorf("got radius %f without a range, expected infinity", l.BoundingSphere().Radius)
+	}
+
+	l.Range = 3
+	sphere := l.BoundingSphere()
+	if sphere.Center != (math.Vec3{0, 2, 0}) || sphere.Radius != 3 {
+		t.Errorf("got sphere at %v with radius %f, expected one at (0, 2, 0) with radius 3", sphere.Center, sphere.Radius)
+	}
+}
+
+func approxEqual(a, b float32) bool {
+	return gomath.Abs(float64(a-b)) <= 1e-5
+}
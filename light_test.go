@@ -25,7 +25,7 @@ func TestMain(m *testing.M) {
 	l1 := light.NewPointLight(math.Vec3{0, 0, 1})
 	l1.Place(math.Vec3{0, 8, 0})
 	l1.CastShadows = true
-	l1.Attenuation = 0.01
+	l1.AttenuationQuadratic = 0.01
 
 	l2 := light.NewSpotLight(math.Vec3{1, 0, 0})
 	l2.CastShadows = true
@@ -37,7 +37,7 @@ func TestMain(m *testing.M) {
 
 	l3 := light.NewPointLight(math.Vec3{0, 1, 0})
 	l3.CastShadows = true
-	l3.Attenuation = 0.01
+	l3.AttenuationQuadratic = 0.01
 
 	c := camera.NewPerspectiveCamera(60, 1, 0.1, 50)
 	c.Place(math.Vec3{0, 1, +10})
@@ -133,6 +133,10 @@ func NewSphere(center math.Vec3, radius float32) *Sphere {
 	return &s
 }
 
+func (s *Sphere) IntersectsSphere(o *Sphere) bool {
+	return s.Center.Sub(o.Center).Length() < s.Radius+o.Radius
+}
+
 func (s *Sphere) Geometry(n int) *Geometry {
 	// TODO: fix tangent/bitangent artifacts on top and bottom
 
@@ -372,6 +376,42 @@ func (c *Cone) Up() math.Vec3 {
 	return c.Tip.Sub(c.Base).Norm()
 }
 
+func (c *Cone) BoundingSphere() *Sphere {
+	h := c.Height()
+	center := c.Base.Add(c.Up().Scale(h / 2))
+	radius := float32(gomath.Sqrt(float64(h*h/4 + c.Radius*c.Radius)))
+	return NewSphere(center, radius)
+}
+
+// IntersectsSphere conservatively tests whether the cone and the sphere overlap
+func (c *Cone) IntersectsSphere(s *Sphere) bool {
+	return c.intersectsSphere(s, false)
+}
+
+// ExtendedIntersectsSphere is like IntersectsSphere, but for the cone
+// extended infinitely beyond its base
+func (c *Cone) ExtendedIntersectsSphere(s *Sphere) bool {
+	return c.intersectsSphere(s, true)
+}
+
+func (c *Cone) intersectsSphere(s *Sphere, extended bool) bool {
+	h := c.Height()
+	axis := c.Up().Scale(-1) // from tip to base
+	v := s.Center.Sub(c.Tip)
+	t := v.Dot(axis)
+	if t < -s.Radius {
+		return false // behind tip
+	}
+	if !extended && t > h+s.Radius {
+		return false // beyond base
+	}
+
+	// distance from center to the slanted surface
+	perp := v.Sub(axis.Scale(t)).Length()
+	slant := float32(gomath.Sqrt(float64(h*h + c.Radius*c.Radius)))
+	return perp*(h/slant)-t*(c.Radius/slant) < s.Radius
+}
+
 func (c *Cone) Geometry(n int) *Geometry {
 	up := c.Up()
 	t1 := up.Normal()
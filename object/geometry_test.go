@@ -0,0 +1,53 @@
+package object
+
+import (
+	"github.com/hersle/gl3d/math"
+	"testing"
+)
+
+func TestConeIntersectsSphere(t *testing.T) {
+	// opens from the origin along -z, with a half angle of atan(1/2)
+	cone := NewCone(math.Vec3{0, 0, -10}, math.Vec3{0, 0, 0}, 5)
+
+	tests := []struct {
+		center     math.Vec3
+		radius     float32
+		intersects bool
+	}{
+		{math.Vec3{0, 0, -5}, 1, true},     // on the axis
+		{math.Vec3{4, 0, -5}, 2, true},     // overlaps the slanted surface
+		{math.Vec3{4, 0, -5}, 1, false},    // just outside the slanted surface
+		{math.Vec3{0, 0, 0.5}, 1, true},    // overlaps the tip
+		{math.Vec3{0, 0, 5}, 1, false},     // behind the tip
+		{math.Vec3{0, 0, -20}, 1, false},   // beyond the base
+		{math.Vec3{10, 0, -5}, 1, false},   // beside the cone
+		{math.Vec3{0, -20, -5}, 30, true},  // contains the cone
+	}
+	for _, test := range tests {
+		sphere := NewSphere(test.center, test.radius)
+		intersects := cone.IntersectsSphere(sphere)
+		if intersects != test.intersects {
+			t.Errorf("sphere at %v with radius %f: got %t, expected %t", test.center, test.radius, intersects, test.intersects)
+		}
+	}
+
+	// the extended cone only differs beyond the base
+	extendedTests := []struct {
+		center     math.Vec3
+		radius     float32
+		intersects bool
+	}{
+		{math.Vec3{0, 0, -5}, 1, true},
+		{math.Vec3{0, 0, 5}, 1, false},
+		{math.Vec3{0, 0, -20}, 1, true},   // beyond the base
+		{math.Vec3{9, 0, -20}, 1, true},   // inside the slanted surface
+		{math.Vec3{12, 0, -20}, 1, false}, // outside the slanted surface
+	}
+	for _, test := range extendedTests {
+		sphere := NewSphere(test.center, test.radius)
+		intersects := cone.ExtendedIntersectsSphere(sphere)
+		if intersects != test.intersects {
+			t.Errorf("sphere at %v with radius %f: got %t, expected %t for the extended cone", test.center, test.radius, intersects, test.intersects)
+		}
+	}
+}
@@ -29,9 +29,9 @@ type ArrowProgram struct {
 func NewArrowProgram() *ArrowProgram {
 	var sp ArrowProgram
 
-	vFile := "render/shaders/arrowvshader.glsl" // TODO: make independent from executable directory
-	fFile := "render/shaders/arrowfshader.glsl" // TODO: make independent from executable directory
-	sp.Program = graphics.ReadProgram(vFile, fFile, "")
+	vFile := "arrowvshader.glsl"
+	fFile := "arrowfshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
 
 	sp.Position = sp.InputByName("position")
 	sp.ModelMatrix = sp.UniformByName("modelMatrix")
@@ -0,0 +1,187 @@
+package render
+
+import (
+	"github.com/hersle/gl3d/camera"
+	"github.com/hersle/gl3d/graphics"
+	"github.com/hersle/gl3d/light"
+	"github.com/hersle/gl3d/math"
+	gomath "math"
+)
+
+// must match the constants in the mesh fragment shader
+const (
+	clusterTilesX = 16
+	clusterTilesY = 9
+	clusterSlices = 24
+	clusterCount  = clusterTilesX * clusterTilesY * clusterSlices
+
+	// slices are spaced exponentially from a positive near distance,
+	// which orthographic projections need not have
+	minClusterNear = 0.01
+)
+
+const (
+	clusterPointLight = 0
+	clusterSpotLight  = 1
+)
+
+// clusterLight is laid out like the Light struct in the mesh fragment shader
+type clusterLight struct {
+	Position    math.Vec3 // view space
+	Type        float32
+	Color       math.Vec3
+	Range       float32
+	Direction   math.Vec3 // view space
+	CosAngle    float32 // of the outer cone
+	Attenuation math.Vec3 // constant, linear and quadratic
+	CosInnerAngle float32
+}
+
+type cluster struct {
+	Offset uint32
+	Count  uint32
+}
+
+// lightClusters bins lights into screen tiles and exponentially spaced depth
+// slices of the view frustum, so shaders only loop over nearby lights
+type lightClusters struct {
+	lights   []clusterLight
+	lists    [clusterCount][]uint32 // light indices in each cluster
+	clusters []cluster
+	indices  []uint32
+
+	lightBuffer   *graphics.StorageBuffer
+	clusterBuffer *graphics.StorageBuffer
+	indexBuffer   *graphics.StorageBuffer
+
+	viewMatrix       *math.Mat4
+	projectionMatrix *math.Mat4
+	near, far        float32
+}
+
+func newLightClusters() *lightClusters {
+	var lc lightClusters
+	lc.clusters = make([]cluster, clusterCount)
+	lc.lightBuffer = graphics.NewStorageBuffer()
+	lc.clusterBuffer = graphics.NewStorageBuffer()
+	lc.indexBuffer = graphics.NewStorageBuffer()
+	return &lc
+}
+
+func (lc *lightClusters) reset(c camera.Camera) {
+	lc.lights = lc.lights[:0]
+	for i := range lc.lists {
+		lc.lists[i] = lc.lists[i][:0]
+	}
+
+	lc.viewMatrix = c.ViewMatrix()
+	lc.projectionMatrix = c.ProjectionMatrix()
+
+	lc.near, lc.far = clipPlanes(lc.projectionMatrix)
+	lc.near = math.Max(lc.near, minClusterNear)
+	lc.far = math.Max(lc.far, 2*lc.near)
+}
+
+func (lc *lightClusters) addPointLight(l *light.PointLight) {
+	var cl clusterLight
+	cl.Position = l.Position.Vec4(1).Transform(lc.viewMatrix).Vec3()
+	cl.Type = clusterPointLight
+	cl.Color = l.Color.Scale(l.Intensity)
+	cl.Attenuation = attenuationTerms(&l.Attenuation)
+	cl.Range = lightRange(&l.Attenuation)
+	lc.add(cl, cl.Position, l.InfluenceRange())
+}
+
+func (lc *lightClusters) addSpotLight(l *light.SpotLight) {
+	var cl clusterLight
+	cl.Position = l.Position.Vec4(1).Transform(lc.viewMatrix).Vec3()
+	cl.Type = clusterSpotLight
+	cl.Color = l.Color.Scale(l.Intensity)
+	cl.Attenuation = attenuationTerms(&l.Attenuation)
+	cl.Range = lightRange(&l.Attenuation)
+	cl.Direction = l.Forward().Vec4(0).Transform(lc.viewMatrix).Vec3()
+	cl.CosInnerAngle, cl.CosAngle = l.ConeCosines()
+	sphere := l.BoundingSphere()
+	center := sphere.Center.Vec4(1).Transform(lc.viewMatrix).Vec3()
+	lc.add(cl, center, sphere.Radius)
+}
+
+// add inserts a light into all clusters touched by a view space bounding sphere
+func (lc *lightClusters) add(cl clusterLight, center math.Vec3, radius float32) {
+	depth := -center.Z()
+	zmin := depth - radius
+	zmax := depth + radius
+	if zmax < lc.near || zmin > lc.far {
+		graphics.Stats.CountCulledLight()
+		return
+	}
+
+	min, max, visible := projectSphere(center, radius, lc.projectionMatrix, lc.near)
+	if !visible {
+		graphics.Stats.CountCulledLight()
+		return
+	}
+
+	slice1, slice2 := lc.slice(zmin), lc.slice(zmax)
+	tileX1, tileX2 := tile(min.X(), clusterTilesX), tile(max.X(), clusterTilesX)
+	tileY1, tileY2 := tile(min.Y(), clusterTilesY), tile(max.Y(), clusterTilesY)
+
+	index := uint32(len(lc.lights))
+	lc.lights = append(lc.lights, cl)
+	for slice := slice1; slice <= slice2; slice++ {
+		for tileY := tileY1; tileY <= tileY2; tileY++ {
+			for tileX := tileX1; tileX <= tileX2; tileX++ {
+				i := (slice*clusterTilesY+tileY)*clusterTilesX + tileX
+				lc.lists[i] = append(lc.lists[i], index)
+			}
+		}
+	}
+}
+
+// slice returns the depth slice containing the view space depth
+func (lc *lightClusters) slice(depth float32) int {
+	if depth <= lc.near {
+		return 0
+	}
+	if depth >= lc.far {
+		return clusterSlices - 1
+	}
+	t := gomath.Log(float64(depth/lc.near)) / gomath.Log(float64(lc.far/lc.near))
+	return clampInt(int(t*clusterSlices), 0, clusterSlices-1)
+}
+
+// tile returns the tile containing the normalized device coordinate
+func tile(ndc float32, tiles int) int {
+	return clampInt(int((ndc+1)/2*float32(tiles)), 0, tiles-1)
+}
+
+func clampInt(i, min, max int) int {
+	if i < min {
+		return min
+	}
+	if i > max {
+		return max
+	}
+	return i
+}
+
+func (lc *lightClusters) empty() bool {
+	return len(lc.lights) == 0
+}
+
+// upload flattens the cluster lists and copies everything to the GPU
+func (lc *lightClusters) upload() {
+	lc.indices = lc.indices[:0]
+	for i, list := range lc.lists {
+		lc.clusters[i].Offset = uint32(len(lc.indices))
+		lc.clusters[i].Count = uint32(len(list))
+		lc.indices = append(lc.indices, list...)
+	}
+	if len(lc.indices) == 0 {
+		lc.indices = append(lc.indices, 0) // avoid an empty buffer
+	}
+
+	lc.lightBuffer.SetData(lc.lights)
+	lc.clusterBuffer.SetData(lc.clusters)
+	lc.indexBuffer.SetData(lc.indices)
+}
@@ -0,0 +1,90 @@
+package render
+
+import (
+	"github.com/hersle/gl3d/math"
+	gomath "math"
+	"testing"
+)
+
+func approxEqual(a, b, tolerance float32) bool {
+	return gomath.Abs(float64(a-b)) <= float64(tolerance)
+}
+
+func TestClipPlanes(t *testing.T) {
+	var proj math.Mat4
+	proj.Perspective(gomath.Pi/3, 16.0/9.0, 0.1, 100)
+	near, far := clipPlanes(&proj)
+	if !approxEqual(near, 0.1, 1e-4) || !approxEqual(far, 100, 1e-1) {
+		t.Errorf("perspective: got near %f and far %f, expected 0.1 and 100", near, far)
+	}
+
+	var translation math.Mat4
+	proj.OrthoCentered(math.Vec3{10, 10, 49})
+	proj.Mult(translation.Translation(math.Vec3{0, 0, 49.0/2 + 1}))
+	near, far = clipPlanes(&proj)
+	if !approxEqual(near, 1, 1e-4) || !approxEqual(far, 50, 1e-3) {
+		t.Errorf("orthographic: got near %f and far %f, expected 1 and 50", near, far)
+	}
+}
+
+func TestClusterSlice(t *testing.T) {
+	var lc lightClusters
+	lc.near, lc.far = 0.1, 100
+
+	tests := []struct {
+		depth float32
+		slice int
+	}{
+		{-1, 0},
+		{0.05, 0},
+		{0.1, 0},
+		{0.1001, 0},
+		{99.9, clusterSlices - 1},
+		{100, clusterSlices - 1},
+		{1000, clusterSlices - 1},
+
+		// one slice spans a factor (far/near)^(1/slices) = 1000^(1/24) in depth
+		{0.1 * float32(gomath.Pow(1000, 1.5/clusterSlices)), 1},
+		{0.1 * float32(gomath.Pow(1000, 12.5/clusterSlices)), 12},
+	}
+	for _, test := range tests {
+		slice := lc.slice(test.depth)
+		if slice != test.slice {
+			t.Errorf("depth %f is in slice %d, expected %d", test.depth, slice, test.slice)
+		}
+	}
+
+	// slices must not decrease with depth
+	last := 0
+	for depth := float32(0.1); depth < 100; depth *= 1.01 {
+		slice := lc.slice(depth)
+		if slice < last {
+			t.Errorf("depth %f is in slice %d before slice %d", depth, slice, last)
+		}
+		last = slice
+	}
+}
+
+func TestClusterTile(t *testing.T) {
+	tests := []struct {
+		ndc   float32
+		tiles int
+		tile  int
+	}{
+		{-2, 16, 0},
+		{-1, 16, 0},
+		{-1 + 1.5/8, 16, 1},
+		{0, 16, 8},
+		{-0.001, 16, 7},
+		{1, 16, 15},
+		{2, 16, 15},
+		{0, 9, 4},
+		{1, 9, 8},
+	}
+	for _, test := range tests {
+		tile := tile(test.ndc, test.tiles)
+		if tile != test.tile {
+			t.Errorf("ndc %f is in tile %d of %d, expected %d", test.ndc, tile, test.tiles, test.tile)
+		}
+	}
+}
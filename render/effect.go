@@ -4,6 +4,7 @@ import (
 	"github.com/hersle/gl3d/graphics"
 	"github.com/hersle/gl3d/math"
 	"github.com/hersle/gl3d/camera"
+	"github.com/hersle/gl3d/light"
 )
 
 type EffectRenderer struct {
@@ -11,10 +12,51 @@ type EffectRenderer struct {
 	renderOpts *graphics.RenderOptions
 
 	invProjectionMatrix math.Mat4
+	invViewMatrix math.Mat4
 
 	fogSp *FogProgram
+	noSkybox *graphics.CubeMap
 
 	gaussianSp *GaussianProgram
+
+	luminanceSp *LuminanceProgram
+	adaptSp *AdaptProgram
+	toneMapSp *ToneMapProgram
+	logLuminanceMap *graphics.Texture2D // average in the smallest mipmap
+	luminanceMap *graphics.Texture2D // 1x1 adapted average luminance
+
+	brightSp *BrightProgram
+	scaleSp *ScaleProgram
+	bloomLevels []*graphics.Texture2D // halving in size
+	bloomExtras []*graphics.Texture2D // for blurring
+
+	fxaaSp *FXAAProgram
+
+	colorGradeSp *ColorGradeProgram
+	identityLUT *graphics.Texture3D
+	luts map[*ColorLUT]*graphics.Texture3D
+	lut1, lut2 *ColorLUT // the tables graded with last
+
+	dofSp *DepthOfFieldProgram
+
+	ssrSp *ReflectionProgram
+
+	outlineSp *OutlineProgram
+	noNormals *graphics.Texture2D // when outlining without normals
+
+	scatterSp *ScatterProgram
+	scatterCompositeSp *ScatterCompositeProgram
+	scatterMap *graphics.Texture2D // half resolution
+	noShadowMap *graphics.Texture2D
+
+	motionBlurSp *MotionBlurProgram
+	invProjViewMatrix math.Mat4
+	noVelocity *graphics.Texture2D
+
+	temporalSp *TemporalProgram
+	history [2]*graphics.Texture2D // previous and current antialiased scene
+	historyIndex int
+	historyValid bool
 }
 
 type FogProgram struct {
@@ -23,7 +65,17 @@ type FogProgram struct {
 	position *graphics.Input
 	depthMap *graphics.Uniform
 	invProjectionMatrix *graphics.Uniform
-	camFar *graphics.Uniform
+	invViewMatrix *graphics.Uniform
+	skybox *graphics.Uniform
+	mode *graphics.Uniform
+	fogColor *graphics.Uniform
+	density *graphics.Uniform
+	start *graphics.Uniform
+	end *graphics.Uniform
+	heightDensity *graphics.Uniform
+	heightFalloff *graphics.Uniform
+	height *graphics.Uniform
+	skyboxTint *graphics.Uniform
 
 	color *graphics.Output
 }
@@ -39,6 +91,187 @@ type GaussianProgram struct {
 	stddev *graphics.Uniform
 }
 
+type LuminanceProgram struct {
+	*graphics.Program
+
+	position *graphics.Input
+	inTexture *graphics.Uniform
+	color *graphics.Output
+}
+
+type AdaptProgram struct {
+	*graphics.Program
+
+	position *graphics.Input
+	logLuminanceMap *graphics.Uniform
+	adaptation *graphics.Uniform
+	color *graphics.Output
+}
+
+type ToneMapProgram struct {
+	*graphics.Program
+
+	position *graphics.Input
+	inTexture *graphics.Uniform
+	luminanceMap *graphics.Uniform
+	operator *graphics.Uniform
+	exposure *graphics.Uniform
+	autoExposure *graphics.Uniform
+	color *graphics.Output
+}
+
+type BrightProgram struct {
+	*graphics.Program
+
+	position *graphics.Input
+	inTexture *graphics.Uniform
+	threshold *graphics.Uniform
+	color *graphics.Output
+}
+
+type ScaleProgram struct {
+	*graphics.Program
+
+	position *graphics.Input
+	inTexture *graphics.Uniform
+	factor *graphics.Uniform
+	color *graphics.Output
+}
+
+type ColorGradeProgram struct {
+	*graphics.Program
+
+	position *graphics.Input
+	inTexture *graphics.Uniform
+	lut1 *graphics.Uniform
+	lut2 *graphics.Uniform
+	blend *graphics.Uniform
+	color *graphics.Output
+}
+
+type FXAAProgram struct {
+	*graphics.Program
+
+	position *graphics.Input
+	inTexture *graphics.Uniform
+	color *graphics.Output
+}
+
+type ReflectionProgram struct {
+	*graphics.Program
+
+	position *graphics.Input
+	inTexture *graphics.Uniform
+	depthMap *graphics.Uniform
+	surfaceMap *graphics.Uniform
+	skybox *graphics.Uniform
+	hasSkybox *graphics.Uniform
+	projectionMatrix *graphics.Uniform
+	invProjectionMatrix *graphics.Uniform
+	invViewMatrix *graphics.Uniform
+	maxSteps *graphics.Uniform
+	refineSteps *graphics.Uniform
+	stepSize *graphics.Uniform
+	thickness *graphics.Uniform
+	intensity *graphics.Uniform
+	debug *graphics.Uniform
+	color *graphics.Output
+}
+
+type OutlineProgram struct {
+	*graphics.Program
+
+	position *graphics.Input
+	inTexture *graphics.Uniform
+	depthMap *graphics.Uniform
+	normalMap *graphics.Uniform
+	hasNormals *graphics.Uniform
+	invProjectionMatrix *graphics.Uniform
+	outlineColor *graphics.Uniform
+	thickness *graphics.Uniform
+	depthThreshold *graphics.Uniform
+	normalThreshold *graphics.Uniform
+	color *graphics.Output
+}
+
+type ScatterProgram struct {
+	*graphics.Program
+
+	position *graphics.Input
+	depthMap *graphics.Uniform
+	invProjectionMatrix *graphics.Uniform
+	invViewMatrix *graphics.Uniform
+	lightType *graphics.Uniform
+	lightPosition *graphics.Uniform
+	lightDirection *graphics.Uniform
+	lightColor *graphics.Uniform
+	lightAttenuation *graphics.Uniform
+	lightRange *graphics.Uniform
+	lightCosAngle *graphics.Uniform
+	lightCosInnerAngle *graphics.Uniform
+	lightFar *graphics.Uniform
+	shadowProjectionViewMatrix *graphics.Uniform
+	shadowMap *graphics.Uniform
+	steps *graphics.Uniform
+	density *graphics.Uniform
+	anisotropy *graphics.Uniform
+	maxDistance *graphics.Uniform
+	color *graphics.Output
+}
+
+type ScatterCompositeProgram struct {
+	*graphics.Program
+
+	position *graphics.Input
+	inTexture *graphics.Uniform
+	scatterMap *graphics.Uniform
+	depthMap *graphics.Uniform
+	invProjectionMatrix *graphics.Uniform
+	color *graphics.Output
+}
+
+type DepthOfFieldProgram struct {
+	*graphics.Program
+
+	position *graphics.Input
+	inTexture *graphics.Uniform
+	depthMap *graphics.Uniform
+	invProjectionMatrix *graphics.Uniform
+	focalDistance *graphics.Uniform
+	focalLength *graphics.Uniform
+	aperture *graphics.Uniform
+	sensorHeight *graphics.Uniform
+	autoFocus *graphics.Uniform
+	maxRadius *graphics.Uniform
+	color *graphics.Output
+}
+
+type MotionBlurProgram struct {
+	*graphics.Program
+
+	position *graphics.Input
+	inTexture *graphics.Uniform
+	depthMap *graphics.Uniform
+	velocityMap *graphics.Uniform
+	objectMotion *graphics.Uniform
+	invProjectionViewMatrix *graphics.Uniform
+	previousProjectionViewMatrix *graphics.Uniform
+	shutter *graphics.Uniform
+	color *graphics.Output
+}
+
+type TemporalProgram struct {
+	*graphics.Program
+
+	position *graphics.Input
+	currentTexture *graphics.Uniform
+	historyTexture *graphics.Uniform
+	velocityMap *graphics.Uniform
+	historyValid *graphics.Uniform
+	blend *graphics.Uniform
+	color *graphics.Output
+}
+
 func NewEffectRenderer() *EffectRenderer {
 	var r EffectRenderer
 
@@ -54,10 +287,61 @@ func NewEffectRenderer() *EffectRenderer {
 
 	r.fogSp = NewFogProgram()
 	r.fogSp.position.SetSourceVertex(r.vbo, 0)
+	r.noSkybox = graphics.NewUniformCubeMap(math.Vec4{1, 1, 1, 1})
 
 	r.gaussianSp = NewGaussianProgram()
 	r.gaussianSp.position.SetSourceVertex(r.vbo, 0)
 
+	r.luminanceSp = NewLuminanceProgram()
+	r.luminanceSp.position.SetSourceVertex(r.vbo, 0)
+
+	r.adaptSp = NewAdaptProgram()
+	r.adaptSp.position.SetSourceVertex(r.vbo, 0)
+
+	r.toneMapSp = NewToneMapProgram()
+	r.toneMapSp.position.SetSourceVertex(r.vbo, 0)
+
+	r.logLuminanceMap = graphics.NewColorTexture2D(graphics.LinearFilter, graphics.EdgeClampWrap, 256, 256, 1, 16, true, true)
+	r.luminanceMap = graphics.NewColorTexture2D(graphics.NearestFilter, graphics.EdgeClampWrap, 1, 1, 1, 16, true, false)
+	r.luminanceMap.Clear(math.Vec4{0.18, 0, 0, 0}) // start at middle gray
+
+	r.brightSp = NewBrightProgram()
+	r.brightSp.position.SetSourceVertex(r.vbo, 0)
+
+	r.scaleSp = NewScaleProgram()
+	r.scaleSp.position.SetSourceVertex(r.vbo, 0)
+
+	r.fxaaSp = NewFXAAProgram()
+	r.fxaaSp.position.SetSourceVertex(r.vbo, 0)
+
+	r.colorGradeSp = NewColorGradeProgram()
+	r.colorGradeSp.position.SetSourceVertex(r.vbo, 0)
+	r.luts = make(map[*ColorLUT]*graphics.Texture3D)
+	r.identityLUT = r.lutTexture(NewIdentityColorLUT(2))
+
+	r.ssrSp = NewReflectionProgram()
+	r.ssrSp.position.SetSourceVertex(r.vbo, 0)
+
+	r.outlineSp = NewOutlineProgram()
+	r.outlineSp.position.SetSourceVertex(r.vbo, 0)
+	r.noNormals = graphics.NewUniformTexture2D(math.Vec4{0, 0, 0, 0})
+
+	r.scatterSp = NewScatterProgram()
+	r.scatterSp.position.SetSourceVertex(r.vbo, 0)
+	r.scatterCompositeSp = NewScatterCompositeProgram()
+	r.scatterCompositeSp.position.SetSourceVertex(r.vbo, 0)
+	r.noShadowMap = graphics.NewUniformTexture2D(math.Vec4{1, 1, 1, 1})
+
+	r.dofSp = NewDepthOfFieldProgram()
+	r.dofSp.position.SetSourceVertex(r.vbo, 0)
+
+	r.motionBlurSp = NewMotionBlurProgram()
+	r.motionBlurSp.position.SetSourceVertex(r.vbo, 0)
+	r.noVelocity = graphics.NewUniformTexture2D(math.Vec4{0, 0, 0, 0})
+
+	r.temporalSp = NewTemporalProgram()
+	r.temporalSp.position.SetSourceVertex(r.vbo, 0)
+
 	r.renderOpts = graphics.NewRenderOptions()
 	r.renderOpts.Primitive = graphics.Triangles
 	//r.renderOpts.Framebuffer = r.framebuffer
@@ -65,7 +349,12 @@ func NewEffectRenderer() *EffectRenderer {
 	return &r
 }
 
-func (r *EffectRenderer) RenderFog(c camera.Camera, depthMap, fogTarget *graphics.Texture2D) {
+// RenderFog blends fogTarget toward the fog color with the distance in
+// depthMap. The skybox can be nil.
+func (r *EffectRenderer) RenderFog(c camera.Camera, depthMap, fogTarget *graphics.Texture2D, fog *Fog, skybox *graphics.CubeMap) {
+	graphics.BeginPass("fog")
+	defer graphics.EndPass()
+
 	r.fogSp.color.Set(fogTarget)
 
 	r.fogSp.depthMap.Set(depthMap)
@@ -75,10 +364,25 @@ func (r *EffectRenderer) RenderFog(c camera.Camera, depthMap, fogTarget *graphic
 	r.invProjectionMatrix.Invert()
 	r.fogSp.invProjectionMatrix.Set(&r.invProjectionMatrix)
 
-	switch c.(type) {
-	case *camera.PerspectiveCamera:
-		c := c.(*camera.PerspectiveCamera)
-		r.fogSp.camFar.Set(c.Far)
+	r.invViewMatrix.Identity()
+	r.invViewMatrix.Mult(c.ViewMatrix())
+	r.invViewMatrix.Invert()
+	r.fogSp.invViewMatrix.Set(&r.invViewMatrix)
+
+	r.fogSp.mode.Set(int(fog.Mode))
+	r.fogSp.fogColor.Set(fog.Color)
+	r.fogSp.density.Set(fog.Density)
+	r.fogSp.start.Set(fog.Start)
+	r.fogSp.end.Set(fog.End)
+	r.fogSp.heightDensity.Set(fog.HeightDensity)
+	r.fogSp.heightFalloff.Set(fog.HeightFalloff)
+	r.fogSp.height.Set(fog.Height)
+	if skybox == nil {
+		r.fogSp.skybox.Set(r.noSkybox)
+		r.fogSp.skyboxTint.Set(float32(0))
+	} else {
+		r.fogSp.skybox.Set(skybox)
+		r.fogSp.skyboxTint.Set(fog.SkyboxTint)
 	}
 
 	r.renderOpts.Blending = graphics.AlphaBlending
@@ -87,6 +391,14 @@ func (r *EffectRenderer) RenderFog(c camera.Camera, depthMap, fogTarget *graphic
 }
 
 func (r *EffectRenderer) RenderGaussianBlur(target, extra *graphics.Texture2D, stddev float32) {
+	graphics.BeginPass("blur")
+	defer graphics.EndPass()
+
+	r.gaussianBlur(target, extra, stddev)
+}
+
+// gaussianBlur blurs target inside the current pass
+func (r *EffectRenderer) gaussianBlur(target, extra *graphics.Texture2D, stddev float32) {
 	r.renderOpts.Blending = graphics.NoBlending
 
 	r.gaussianSp.stddev.Set(stddev)
@@ -104,17 +416,459 @@ func (r *EffectRenderer) RenderGaussianBlur(target, extra *graphics.Texture2D, s
 	r.gaussianSp.Render(6, r.renderOpts)
 }
 
+const bloomLevelCount = 5
+
+// RenderBloom spreads light from the parts of target brighter than threshold
+// to its surroundings
+func (r *EffectRenderer) RenderBloom(target *graphics.Texture2D, threshold, intensity, radius float32) {
+	graphics.BeginPass("bloom")
+	defer graphics.EndPass()
+
+	r.makeBloomLevels(target.Width(), target.Height())
+
+	r.renderOpts.Blending = graphics.NoBlending
+
+	// the bright pass averages 2x2 pixels when halving the size
+	r.brightSp.inTexture.Set(target)
+	r.brightSp.threshold.Set(threshold)
+	r.brightSp.color.Set(r.bloomLevels[0])
+	r.brightSp.Render(6, r.renderOpts)
+
+	for i := 1; i < bloomLevelCount; i++ {
+		r.scaleSp.inTexture.Set(r.bloomLevels[i-1])
+		r.scaleSp.factor.Set(float32(1))
+		r.scaleSp.color.Set(r.bloomLevels[i])
+		r.scaleSp.Render(6, r.renderOpts)
+	}
+
+	// the same radius on smaller levels covers larger areas
+	for i := 0; i < bloomLevelCount; i++ {
+		r.gaussianBlur(r.bloomLevels[i], r.bloomExtras[i], radius)
+	}
+
+	r.renderOpts.Blending = graphics.AdditiveBlending
+	r.scaleSp.factor.Set(intensity / bloomLevelCount)
+	r.scaleSp.color.Set(target)
+	for i := 0; i < bloomLevelCount; i++ {
+		r.scaleSp.inTexture.Set(r.bloomLevels[i])
+		r.scaleSp.Render(6, r.renderOpts)
+	}
+}
+
+func (r *EffectRenderer) makeBloomLevels(width, height int) {
+	if len(r.bloomLevels) > 0 && r.bloomLevels[0].Width() == width/2 && r.bloomLevels[0].Height() == height/2 {
+		return
+	}
+
+	for i := range r.bloomLevels {
+		r.bloomLevels[i].Delete()
+		r.bloomExtras[i].Delete()
+	}
+	r.bloomLevels = r.bloomLevels[:0]
+	r.bloomExtras = r.bloomExtras[:0]
+	for i := 0; i < bloomLevelCount; i++ {
+		if width > 1 {
+			width /= 2
+		}
+		if height > 1 {
+			height /= 2
+		}
+		level := graphics.NewColorTexture2D(graphics.LinearFilter, graphics.EdgeClampWrap, width, height, 4, 16, true, false)
+		extra := graphics.NewColorTexture2D(graphics.LinearFilter, graphics.EdgeClampWrap, width, height, 4, 16, true, false)
+		r.bloomLevels = append(r.bloomLevels, level)
+		r.bloomExtras = append(r.bloomExtras, extra)
+	}
+}
+
+// RenderToneMapping maps the high dynamic range colors in source to
+// displayable colors in target
+func (r *EffectRenderer) RenderToneMapping(source, target *graphics.Texture2D, operator ToneMapOperator, exposure float32, autoExposure bool, adaptation float32) {
+	graphics.BeginPass("tonemap")
+	defer graphics.EndPass()
+
+	r.renderOpts.Blending = graphics.NoBlending
+
+	if autoExposure {
+		r.luminanceSp.inTexture.Set(source)
+		r.luminanceSp.color.Set(r.logLuminanceMap)
+		r.luminanceSp.Render(6, r.renderOpts)
+		r.logLuminanceMap.GenerateMipmap()
+
+		// blend towards the new luminance to adapt gradually
+		var opts graphics.RenderOptions
+		opts.Primitive = graphics.Triangles
+		opts.Blending = graphics.AlphaBlending
+		r.adaptSp.logLuminanceMap.Set(r.logLuminanceMap)
+		r.adaptSp.adaptation.Set(adaptation)
+		r.adaptSp.color.Set(r.luminanceMap)
+		r.adaptSp.Render(6, &opts)
+	}
+
+	r.toneMapSp.inTexture.Set(source)
+	r.toneMapSp.luminanceMap.Set(r.luminanceMap)
+	r.toneMapSp.operator.Set(int(operator))
+	r.toneMapSp.exposure.Set(exposure)
+	if autoExposure {
+		r.toneMapSp.autoExposure.Set(int32(1))
+	} else {
+		r.toneMapSp.autoExposure.Set(int32(0))
+	}
+	r.toneMapSp.color.Set(target)
+	r.toneMapSp.Render(6, r.renderOpts)
+}
+
+// RenderColorGrading maps the colors of source through a blend of two lookup
+// tables, where nil means no change. It expects displayable colors.
+func (r *EffectRenderer) RenderColorGrading(source, target *graphics.Texture2D, lut1, lut2 *ColorLUT, blend float32) {
+	graphics.BeginPass("colorgrade")
+	defer graphics.EndPass()
+
+	// free the textures of tables that have been replaced
+	if lut1 != r.lut1 || lut2 != r.lut2 {
+		for lut, tex := range r.luts {
+			if lut != lut1 && lut != lut2 && tex != r.identityLUT {
+				tex.Delete()
+				delete(r.luts, lut)
+			}
+		}
+		r.lut1, r.lut2 = lut1, lut2
+	}
+
+	r.renderOpts.Blending = graphics.NoBlending
+	r.colorGradeSp.inTexture.Set(source)
+	r.colorGradeSp.lut1.Set(r.lutTexture(lut1))
+	r.colorGradeSp.lut2.Set(r.lutTexture(lut2))
+	r.colorGradeSp.blend.Set(blend)
+	r.colorGradeSp.color.Set(target)
+	r.colorGradeSp.Render(6, r.renderOpts)
+}
+
+// lutTexture returns the texture of a lookup table, uploading it the first time
+func (r *EffectRenderer) lutTexture(lut *ColorLUT) *graphics.Texture3D {
+	if lut == nil {
+		return r.identityLUT
+	}
+
+	tex, found := r.luts[lut]
+	if !found {
+		tex = graphics.NewColorTexture3D(graphics.LinearFilter, graphics.EdgeClampWrap, lut.Size, lut.Size, lut.Size, 3, 16, true)
+		tex.SetData(lut.Data)
+		r.luts[lut] = tex
+	}
+	return tex
+}
+
+// RenderFXAA smooths jagged edges found in the colors of source.
+// It expects displayable colors, i.e. after tone mapping.
+func (r *EffectRenderer) RenderFXAA(source, target *graphics.Texture2D) {
+	graphics.BeginPass("fxaa")
+	defer graphics.EndPass()
+
+	r.renderOpts.Blending = graphics.NoBlending
+	r.fxaaSp.inTexture.Set(source)
+	r.fxaaSp.color.Set(target)
+	r.fxaaSp.Render(6, r.renderOpts)
+}
+
+// RenderReflections adds reflections of what is on screen to the specular
+// surfaces in surfaceMap, falling back to the skybox (which can be nil)
+func (r *EffectRenderer) RenderReflections(c camera.Camera, source, depthMap, surfaceMap, target *graphics.Texture2D, skybox *graphics.CubeMap, refl *Reflections) {
+	graphics.BeginPass("ssr")
+	defer graphics.EndPass()
+
+	r.invProjectionMatrix.Identity()
+	r.invProjectionMatrix.Mult(c.ProjectionMatrix())
+	r.invProjectionMatrix.Invert()
+
+	r.invViewMatrix.Identity()
+	r.invViewMatrix.Mult(c.ViewMatrix())
+	r.invViewMatrix.Invert()
+
+	r.renderOpts.Blending = graphics.NoBlending
+	r.ssrSp.inTexture.Set(source)
+	r.ssrSp.depthMap.Set(depthMap)
+	r.ssrSp.surfaceMap.Set(surfaceMap)
+	if skybox == nil {
+		r.ssrSp.skybox.Set(r.noSkybox)
+		r.ssrSp.hasSkybox.Set(int32(0))
+	} else {
+		r.ssrSp.skybox.Set(skybox)
+		r.ssrSp.hasSkybox.Set(int32(1))
+	}
+	r.ssrSp.projectionMatrix.Set(c.ProjectionMatrix())
+	r.ssrSp.invProjectionMatrix.Set(&r.invProjectionMatrix)
+	r.ssrSp.invViewMatrix.Set(&r.invViewMatrix)
+	r.ssrSp.maxSteps.Set(refl.MaxSteps)
+	r.ssrSp.refineSteps.Set(refl.RefineSteps)
+	r.ssrSp.stepSize.Set(refl.StepSize)
+	r.ssrSp.thickness.Set(refl.Thickness)
+	r.ssrSp.intensity.Set(refl.Intensity)
+	if refl.Debug {
+		r.ssrSp.debug.Set(int32(1))
+	} else {
+		r.ssrSp.debug.Set(int32(0))
+	}
+	r.ssrSp.color.Set(target)
+	r.ssrSp.Render(6, r.renderOpts)
+}
+
+// RenderOutline draws lines along discontinuities in depth, and in the
+// geometric normals in normalMap unless it is nil
+func (r *EffectRenderer) RenderOutline(c camera.Camera, source, depthMap, normalMap, target *graphics.Texture2D, outline *Outline) {
+	graphics.BeginPass("outline")
+	defer graphics.EndPass()
+
+	r.invProjectionMatrix.Identity()
+	r.invProjectionMatrix.Mult(c.ProjectionMatrix())
+	r.invProjectionMatrix.Invert()
+
+	r.renderOpts.Blending = graphics.NoBlending
+	r.outlineSp.inTexture.Set(source)
+	r.outlineSp.depthMap.Set(depthMap)
+	if normalMap == nil {
+		r.outlineSp.normalMap.Set(r.noNormals)
+		r.outlineSp.hasNormals.Set(int32(0))
+	} else {
+		r.outlineSp.normalMap.Set(normalMap)
+		r.outlineSp.hasNormals.Set(int32(1))
+	}
+	r.outlineSp.invProjectionMatrix.Set(&r.invProjectionMatrix)
+	r.outlineSp.outlineColor.Set(outline.Color)
+	r.outlineSp.thickness.Set(outline.Thickness)
+	r.outlineSp.depthThreshold.Set(outline.DepthThreshold)
+	r.outlineSp.normalThreshold.Set(outline.NormalThreshold)
+	r.outlineSp.color.Set(target)
+	r.outlineSp.Render(6, r.renderOpts)
+}
+
+// must match the constants in the scattering shader
+const (
+	scatterSpotLight = 0
+	scatterDirLight = 1
+)
+
+// beginScattering prepares to accumulate the light that is scattered toward
+// the camera in a half resolution map
+func (r *EffectRenderer) beginScattering(c camera.Camera, depthMap *graphics.Texture2D, vol *VolumetricLight) {
+	r.makeScatterMap(depthMap.Width()/2, depthMap.Height()/2)
+	r.scatterMap.Clear(math.Vec4{0, 0, 0, 0})
+
+	r.invProjectionMatrix.Identity()
+	r.invProjectionMatrix.Mult(c.ProjectionMatrix())
+	r.invProjectionMatrix.Invert()
+
+	r.invViewMatrix.Identity()
+	r.invViewMatrix.Mult(c.ViewMatrix())
+	r.invViewMatrix.Invert()
+
+	r.scatterSp.depthMap.Set(depthMap)
+	r.scatterSp.invProjectionMatrix.Set(&r.invProjectionMatrix)
+	r.scatterSp.invViewMatrix.Set(&r.invViewMatrix)
+	r.scatterSp.steps.Set(vol.Steps)
+	r.scatterSp.density.Set(vol.Density)
+	r.scatterSp.anisotropy.Set(vol.Anisotropy)
+	r.scatterSp.maxDistance.Set(vol.MaxDistance)
+	r.scatterSp.color.Set(r.scatterMap)
+}
+
+// scatterSpotLight adds the light scattered from l, blocked by its
+// shadow map unless it is nil
+func (r *EffectRenderer) scatterSpotLight(l *light.SpotLight, shadowMap *graphics.Texture2D) {
+	r.scatterSp.lightType.Set(scatterSpotLight)
+	r.scatterSp.lightPosition.Set(l.Position)
+	r.scatterSp.lightDirection.Set(l.Forward())
+	r.scatterSp.lightColor.Set(l.Color.Scale(l.Intensity))
+	r.scatterSp.lightAttenuation.Set(attenuationTerms(&l.Attenuation))
+	r.scatterSp.lightRange.Set(lightRange(&l.Attenuation))
+	cosInner, cosOuter := l.ConeCosines()
+	r.scatterSp.lightCosAngle.Set(cosOuter)
+	r.scatterSp.lightCosInnerAngle.Set(cosInner)
+	r.scatterSp.lightFar.Set(l.ShadowDistance())
+	c := spotShadowCamera(l)
+	r.setScatterShadowMap(c.ProjectionMatrix(), c.ViewMatrix(), shadowMap)
+
+	r.renderOpts.Blending = graphics.AdditiveBlending
+	r.scatterSp.Render(6, r.renderOpts)
+}
+
+// scatterDirectionalLight adds the light scattered from l, blocked by its
+// shadow map unless it is nil
+func (r *EffectRenderer) scatterDirectionalLight(l *light.DirectionalLight, shadowMap *graphics.Texture2D) {
+	r.scatterSp.lightType.Set(scatterDirLight)
+	r.scatterSp.lightDirection.Set(l.Forward())
+	r.scatterSp.lightColor.Set(l.Color.Scale(l.Intensity))
+	r.setScatterShadowMap(l.ProjectionMatrix(), l.ViewMatrix(), shadowMap)
+
+	r.renderOpts.Blending = graphics.AdditiveBlending
+	r.scatterSp.Render(6, r.renderOpts)
+}
+
+func (r *EffectRenderer) setScatterShadowMap(projMat, viewMat *math.Mat4, shadowMap *graphics.Texture2D) {
+	var m math.Mat4
+	m.Identity()
+	m.Mult(projMat)
+	m.Mult(viewMat)
+	r.scatterSp.shadowProjectionViewMatrix.Set(&m)
+	if shadowMap == nil {
+		r.scatterSp.shadowMap.Set(r.noShadowMap)
+	} else {
+		r.scatterSp.shadowMap.Set(shadowMap)
+	}
+}
+
+// endScattering upsamples the scattered light and adds it to source in target
+func (r *EffectRenderer) endScattering(source, depthMap, target *graphics.Texture2D) {
+	r.renderOpts.Blending = graphics.NoBlending
+	r.scatterCompositeSp.inTexture.Set(source)
+	r.scatterCompositeSp.scatterMap.Set(r.scatterMap)
+	r.scatterCompositeSp.depthMap.Set(depthMap)
+	r.scatterCompositeSp.invProjectionMatrix.Set(&r.invProjectionMatrix)
+	r.scatterCompositeSp.color.Set(target)
+	r.scatterCompositeSp.Render(6, r.renderOpts)
+}
+
+func (r *EffectRenderer) makeScatterMap(width, height int) {
+	if r.scatterMap != nil && r.scatterMap.Width() == width && r.scatterMap.Height() == height {
+		return
+	}
+	if r.scatterMap != nil {
+		r.scatterMap.Delete()
+	}
+	r.scatterMap = graphics.NewColorTexture2D(graphics.LinearFilter, graphics.EdgeClampWrap, width, height, 4, 16, true, false)
+}
+
+// RenderDepthOfField blurs source into target like a lens that is focused
+// according to the camera's physical parameters, by at most maxRadius pixels
+func (r *EffectRenderer) RenderDepthOfField(c *camera.PerspectiveCamera, source, depthMap, target *graphics.Texture2D, maxRadius float32) {
+	graphics.BeginPass("dof")
+	defer graphics.EndPass()
+
+	r.invProjectionMatrix.Identity()
+	r.invProjectionMatrix.Mult(c.ProjectionMatrix())
+	r.invProjectionMatrix.Invert()
+
+	r.renderOpts.Blending = graphics.NoBlending
+	r.dofSp.inTexture.Set(source)
+	r.dofSp.depthMap.Set(depthMap)
+	r.dofSp.invProjectionMatrix.Set(&r.invProjectionMatrix)
+	r.dofSp.focalDistance.Set(c.FocusDistance())
+	r.dofSp.focalLength.Set(c.FocalLength())
+	r.dofSp.aperture.Set(c.ApertureDiameter())
+	r.dofSp.sensorHeight.Set(c.SensorHeight)
+	if c.AutoFocus {
+		r.dofSp.autoFocus.Set(int32(1))
+	} else {
+		r.dofSp.autoFocus.Set(int32(0))
+	}
+	r.dofSp.maxRadius.Set(maxRadius)
+	r.dofSp.color.Set(target)
+	r.dofSp.Render(6, r.renderOpts)
+}
+
+// RenderMotionBlur blurs source into target along the motion of each pixel
+// since the previous frame, while the shutter is open. The motion of the
+// camera is reconstructed from depthMap, and moving meshes get their motion
+// from velocityMap if it is not nil.
+func (r *EffectRenderer) RenderMotionBlur(source, depthMap, velocityMap, target *graphics.Texture2D, projViewMat, previousProjViewMat *math.Mat4, shutter float32) {
+	graphics.BeginPass("motionblur")
+	defer graphics.EndPass()
+
+	r.invProjViewMatrix.Identity()
+	r.invProjViewMatrix.Mult(projViewMat)
+	r.invProjViewMatrix.Invert()
+
+	r.renderOpts.Blending = graphics.NoBlending
+	r.motionBlurSp.inTexture.Set(source)
+	r.motionBlurSp.depthMap.Set(depthMap)
+	if velocityMap == nil {
+		r.motionBlurSp.velocityMap.Set(r.noVelocity)
+		r.motionBlurSp.objectMotion.Set(int32(0))
+	} else {
+		r.motionBlurSp.velocityMap.Set(velocityMap)
+		r.motionBlurSp.objectMotion.Set(int32(1))
+	}
+	r.motionBlurSp.invProjectionViewMatrix.Set(&r.invProjViewMatrix)
+	r.motionBlurSp.previousProjectionViewMatrix.Set(previousProjViewMat)
+	r.motionBlurSp.shutter.Set(shutter)
+	r.motionBlurSp.color.Set(target)
+	r.motionBlurSp.Render(6, r.renderOpts)
+}
+
+// RenderTemporalAntialiasing blends target with the history of previous
+// frames, reprojected with the velocities in velocityMap. The frames should
+// be rendered with different subpixel jitter.
+func (r *EffectRenderer) RenderTemporalAntialiasing(target, velocityMap *graphics.Texture2D, blend float32) {
+	graphics.BeginPass("taa")
+	defer graphics.EndPass()
+
+	r.makeHistory(target.Width(), target.Height())
+	previous := r.history[r.historyIndex]
+	current := r.history[1-r.historyIndex]
+
+	r.renderOpts.Blending = graphics.NoBlending
+	r.temporalSp.currentTexture.Set(target)
+	r.temporalSp.historyTexture.Set(previous)
+	r.temporalSp.velocityMap.Set(velocityMap)
+	if r.historyValid {
+		r.temporalSp.historyValid.Set(int32(1))
+	} else {
+		r.temporalSp.historyValid.Set(int32(0))
+	}
+	r.temporalSp.blend.Set(blend)
+	r.temporalSp.color.Set(current)
+	r.temporalSp.Render(6, r.renderOpts)
+
+	// copy back for the following effects
+	r.scaleSp.inTexture.Set(current)
+	r.scaleSp.factor.Set(float32(1))
+	r.scaleSp.color.Set(target)
+	r.scaleSp.Render(6, r.renderOpts)
+
+	r.historyIndex = 1 - r.historyIndex
+	r.historyValid = true
+}
+
+// resetHistory discards the previous frames, e.g. when temporal
+// antialiasing has been off
+func (r *EffectRenderer) resetHistory() {
+	r.historyValid = false
+}
+
+func (r *EffectRenderer) makeHistory(width, height int) {
+	if r.history[0] != nil && r.history[0].Width() == width && r.history[0].Height() == height {
+		return
+	}
+
+	for i := range r.history {
+		if r.history[i] != nil {
+			r.history[i].Delete()
+		}
+		r.history[i] = graphics.NewColorTexture2D(graphics.LinearFilter, graphics.EdgeClampWrap, width, height, 4, 16, true, false)
+	}
+	r.historyValid = false
+}
+
 func NewFogProgram() *FogProgram {
 	var sp FogProgram
 
-	vFile := "render/shaders/fogvshader.glsl" // TODO: make independent from executable directory
-	fFile := "render/shaders/fogfshader.glsl" // TODO: make independent from executable directory
-	sp.Program = graphics.ReadProgram(vFile, fFile, "")
+	vFile := "fogvshader.glsl"
+	fFile := "fogfshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
 
 	sp.position = sp.InputByName("position")
 	sp.depthMap = sp.UniformByName("depthTexture")
 	sp.invProjectionMatrix = sp.UniformByName("invProjectionMatrix")
-	sp.camFar = sp.UniformByName("cameraFar")
+	sp.invViewMatrix = sp.UniformByName("invViewMatrix")
+	sp.skybox = sp.UniformByName("skybox")
+	sp.mode = sp.UniformByName("mode")
+	sp.fogColor = sp.UniformByName("color")
+	sp.density = sp.UniformByName("density")
+	sp.start = sp.UniformByName("start")
+	sp.end = sp.UniformByName("end")
+	sp.heightDensity = sp.UniformByName("heightDensity")
+	sp.heightFalloff = sp.UniformByName("heightFalloff")
+	sp.height = sp.UniformByName("height")
+	sp.skyboxTint = sp.UniformByName("skyboxTint")
 	sp.color = sp.OutputColorByName("fragColor")
 
 	return &sp
@@ -123,9 +877,9 @@ func NewFogProgram() *FogProgram {
 func NewGaussianProgram() *GaussianProgram {
 	var sp GaussianProgram
 
-	vFile := "render/shaders/gaussianvshader.glsl" // TODO: make independent from executable directory
-	fFile := "render/shaders/gaussianfshader.glsl" // TODO: make independent from executable directory
-	sp.Program = graphics.ReadProgram(vFile, fFile, "")
+	vFile := "gaussianvshader.glsl"
+	fFile := "gaussianfshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
 
 	sp.position = sp.InputByName("position")
 	sp.inTexture = sp.UniformByName("inTexture")
@@ -136,3 +890,268 @@ func NewGaussianProgram() *GaussianProgram {
 
 	return &sp
 }
+
+func NewLuminanceProgram() *LuminanceProgram {
+	var sp LuminanceProgram
+
+	vFile := "postvshader.glsl"
+	fFile := "luminancefshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
+
+	sp.position = sp.InputByName("position")
+	sp.inTexture = sp.UniformByName("inTexture")
+	sp.color = sp.OutputColorByName("fragColor")
+
+	return &sp
+}
+
+func NewAdaptProgram() *AdaptProgram {
+	var sp AdaptProgram
+
+	vFile := "postvshader.glsl"
+	fFile := "adaptfshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
+
+	sp.position = sp.InputByName("position")
+	sp.logLuminanceMap = sp.UniformByName("logLuminanceMap")
+	sp.adaptation = sp.UniformByName("adaptation")
+	sp.color = sp.OutputColorByName("fragColor")
+
+	return &sp
+}
+
+func NewToneMapProgram() *ToneMapProgram {
+	var sp ToneMapProgram
+
+	vFile := "postvshader.glsl"
+	fFile := "tonemapfshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
+
+	sp.position = sp.InputByName("position")
+	sp.inTexture = sp.UniformByName("inTexture")
+	sp.luminanceMap = sp.UniformByName("luminanceMap")
+	sp.operator = sp.UniformByName("operator")
+	sp.exposure = sp.UniformByName("exposure")
+	sp.autoExposure = sp.UniformByName("autoExposure")
+	sp.color = sp.OutputColorByName("fragColor")
+
+	return &sp
+}
+
+func NewBrightProgram() *BrightProgram {
+	var sp BrightProgram
+
+	vFile := "postvshader.glsl"
+	fFile := "brightfshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
+
+	sp.position = sp.InputByName("position")
+	sp.inTexture = sp.UniformByName("inTexture")
+	sp.threshold = sp.UniformByName("threshold")
+	sp.color = sp.OutputColorByName("fragColor")
+
+	return &sp
+}
+
+func NewScaleProgram() *ScaleProgram {
+	var sp ScaleProgram
+
+	vFile := "postvshader.glsl"
+	fFile := "scalefshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
+
+	sp.position = sp.InputByName("position")
+	sp.inTexture = sp.UniformByName("inTexture")
+	sp.factor = sp.UniformByName("factor")
+	sp.color = sp.OutputColorByName("fragColor")
+
+	return &sp
+}
+
+func NewFXAAProgram() *FXAAProgram {
+	var sp FXAAProgram
+
+	vFile := "postvshader.glsl"
+	fFile := "fxaafshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
+
+	sp.position = sp.InputByName("position")
+	sp.inTexture = sp.UniformByName("inTexture")
+	sp.color = sp.OutputColorByName("fragColor")
+
+	return &sp
+}
+
+func NewTemporalProgram() *TemporalProgram {
+	var sp TemporalProgram
+
+	vFile := "postvshader.glsl"
+	fFile := "taafshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
+
+	sp.position = sp.InputByName("position")
+	sp.currentTexture = sp.UniformByName("currentTexture")
+	sp.historyTexture = sp.UniformByName("historyTexture")
+	sp.velocityMap = sp.UniformByName("velocityMap")
+	sp.historyValid = sp.UniformByName("historyValid")
+	sp.blend = sp.UniformByName("blend")
+	sp.color = sp.OutputColorByName("fragColor")
+
+	return &sp
+}
+
+func NewDepthOfFieldProgram() *DepthOfFieldProgram {
+	var sp DepthOfFieldProgram
+
+	vFile := "postvshader.glsl"
+	fFile := "doffshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
+
+	sp.position = sp.InputByName("position")
+	sp.inTexture = sp.UniformByName("inTexture")
+	sp.depthMap = sp.UniformByName("depthMap")
+	sp.invProjectionMatrix = sp.UniformByName("invProjectionMatrix")
+	sp.focalDistance = sp.UniformByName("focalDistance")
+	sp.focalLength = sp.UniformByName("focalLength")
+	sp.aperture = sp.UniformByName("aperture")
+	sp.sensorHeight = sp.UniformByName("sensorHeight")
+	sp.autoFocus = sp.UniformByName("autoFocus")
+	sp.maxRadius = sp.UniformByName("maxRadius")
+	sp.color = sp.OutputColorByName("fragColor")
+
+	return &sp
+}
+
+func NewMotionBlurProgram() *MotionBlurProgram {
+	var sp MotionBlurProgram
+
+	vFile := "postvshader.glsl"
+	fFile := "motionblurfshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
+
+	sp.position = sp.InputByName("position")
+	sp.inTexture = sp.UniformByName("inTexture")
+	sp.depthMap = sp.UniformByName("depthMap")
+	sp.velocityMap = sp.UniformByName("velocityMap")
+	sp.objectMotion = sp.UniformByName("objectMotion")
+	sp.invProjectionViewMatrix = sp.UniformByName("invProjectionViewMatrix")
+	sp.previousProjectionViewMatrix = sp.UniformByName("previousProjectionViewMatrix")
+	sp.shutter = sp.UniformByName("shutter")
+	sp.color = sp.OutputColorByName("fragColor")
+
+	return &sp
+}
+
+func NewReflectionProgram() *ReflectionProgram {
+	var sp ReflectionProgram
+
+	vFile := "postvshader.glsl"
+	fFile := "ssrfshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
+
+	sp.position = sp.InputByName("position")
+	sp.inTexture = sp.UniformByName("inTexture")
+	sp.depthMap = sp.UniformByName("depthMap")
+	sp.surfaceMap = sp.UniformByName("surfaceMap")
+	sp.skybox = sp.UniformByName("skybox")
+	sp.hasSkybox = sp.UniformByName("hasSkybox")
+	sp.projectionMatrix = sp.UniformByName("projectionMatrix")
+	sp.invProjectionMatrix = sp.UniformByName("invProjectionMatrix")
+	sp.invViewMatrix = sp.UniformByName("invViewMatrix")
+	sp.maxSteps = sp.UniformByName("maxSteps")
+	sp.refineSteps = sp.UniformByName("refineSteps")
+	sp.stepSize = sp.UniformByName("stepSize")
+	sp.thickness = sp.UniformByName("thickness")
+	sp.intensity = sp.UniformByName("intensity")
+	sp.debug = sp.UniformByName("debug")
+	sp.color = sp.OutputColorByName("fragColor")
+
+	return &sp
+}
+
+func NewColorGradeProgram() *ColorGradeProgram {
+	var sp ColorGradeProgram
+
+	vFile := "postvshader.glsl"
+	fFile := "colorgradefshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
+
+	sp.position = sp.InputByName("position")
+	sp.inTexture = sp.UniformByName("inTexture")
+	sp.lut1 = sp.UniformByName("lut1")
+	sp.lut2 = sp.UniformByName("lut2")
+	sp.blend = sp.UniformByName("blend")
+	sp.color = sp.OutputColorByName("fragColor")
+
+	return &sp
+}
+
+func NewOutlineProgram() *OutlineProgram {
+	var sp OutlineProgram
+
+	vFile := "postvshader.glsl"
+	fFile := "outlinefshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
+
+	sp.position = sp.InputByName("position")
+	sp.inTexture = sp.UniformByName("inTexture")
+	sp.depthMap = sp.UniformByName("depthMap")
+	sp.normalMap = sp.UniformByName("normalMap")
+	sp.hasNormals = sp.UniformByName("hasNormals")
+	sp.invProjectionMatrix = sp.UniformByName("invProjectionMatrix")
+	sp.outlineColor = sp.UniformByName("outlineColor")
+	sp.thickness = sp.UniformByName("thickness")
+	sp.depthThreshold = sp.UniformByName("depthThreshold")
+	sp.normalThreshold = sp.UniformByName("normalThreshold")
+	sp.color = sp.OutputColorByName("fragColor")
+
+	return &sp
+}
+
+func NewScatterProgram() *ScatterProgram {
+	var sp ScatterProgram
+
+	vFile := "postvshader.glsl"
+	fFile := "scatterfshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
+
+	sp.position = sp.InputByName("position")
+	sp.depthMap = sp.UniformByName("depthMap")
+	sp.invProjectionMatrix = sp.UniformByName("invProjectionMatrix")
+	sp.invViewMatrix = sp.UniformByName("invViewMatrix")
+	sp.lightType = sp.UniformByName("lightType")
+	sp.lightPosition = sp.UniformByName("lightPosition")
+	sp.lightDirection = sp.UniformByName("lightDirection")
+	sp.lightColor = sp.UniformByName("lightColor")
+	sp.lightAttenuation = sp.UniformByName("lightAttenuation")
+	sp.lightRange = sp.UniformByName("lightRange")
+	sp.lightCosAngle = sp.UniformByName("lightCosAngle")
+	sp.lightCosInnerAngle = sp.UniformByName("lightCosInnerAngle")
+	sp.lightFar = sp.UniformByName("lightFar")
+	sp.shadowProjectionViewMatrix = sp.UniformByName("shadowProjectionViewMatrix")
+	sp.shadowMap = sp.UniformByName("shadowMap")
+	sp.steps = sp.UniformByName("steps")
+	sp.density = sp.UniformByName("density")
+	sp.anisotropy = sp.UniformByName("anisotropy")
+	sp.maxDistance = sp.UniformByName("maxDistance")
+	sp.color = sp.OutputColorByName("fragColor")
+
+	return &sp
+}
+
+func NewScatterCompositeProgram() *ScatterCompositeProgram {
+	var sp ScatterCompositeProgram
+
+	vFile := "postvshader.glsl"
+	fFile := "scattercompositefshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
+
+	sp.position = sp.InputByName("position")
+	sp.inTexture = sp.UniformByName("inTexture")
+	sp.scatterMap = sp.UniformByName("scatterMap")
+	sp.depthMap = sp.UniformByName("depthMap")
+	sp.invProjectionMatrix = sp.UniformByName("invProjectionMatrix")
+	sp.color = sp.OutputColorByName("fragColor")
+
+	return &sp
+}
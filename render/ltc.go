@@ -0,0 +1,26 @@
+package render
+
+import (
+	"github.com/hersle/gl3d/graphics"
+)
+
+// must match the constants in the lighting shader
+const (
+	ltcSize            = 32
+	maxAreaLightPoints = 16
+)
+
+//go:generate go run ltcfit.go
+
+// newLTCMaps uploads linearly transformed cosines fitted to the GGX specular
+// lobe on a grid of roughnesses (along x) and sqrt(1 - cos(view angle))
+// (along y). The first map holds the nonzero elements of the inverse
+// transformations, and the second the magnitude of the lobe and its part
+// from Fresnel reflection.
+func newLTCMaps() (matrixMap, amplitudeMap *graphics.Texture2D) {
+	matrixMap = graphics.NewColorTexture2D(graphics.LinearFilter, graphics.EdgeClampWrap, ltcSize, ltcSize, 4, 32, true, false)
+	matrixMap.SetData(0, 0, ltcSize, ltcSize, ltcMatrices)
+	amplitudeMap = graphics.NewColorTexture2D(graphics.LinearFilter, graphics.EdgeClampWrap, ltcSize, ltcSize, 4, 32, true, false)
+	amplitudeMap.SetData(0, 0, ltcSize, ltcSize, ltcAmplitudes)
+	return matrixMap, amplitudeMap
+}
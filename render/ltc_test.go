@@ -0,0 +1,38 @@
+package render
+
+import (
+	gomath "math"
+	"testing"
+)
+
+func TestLTCTables(t *testing.T) {
+	if len(ltcMatrices) != ltcSize*ltcSize || len(ltcAmplitudes) != ltcSize*ltcSize {
+		t.Fatalf("got %d matrices and %d amplitudes, expected %d", len(ltcMatrices), len(ltcAmplitudes), ltcSize*ltcSize)
+	}
+
+	for i := range ltcMatrices {
+		m := ltcMatrices[i]
+		a := ltcAmplitudes[i]
+		for _, x := range []float32{m[0], m[1], m[2], m[3], a[0], a[1]} {
+			if gomath.IsNaN(float64(x)) || gomath.IsInf(float64(x), 0) {
+				t.Fatalf("entry %d (%v, %v) is not finite", i, m, a)
+			}
+		}
+
+		// the lobe reflects at most all light, and Fresnel only adds to it
+		if a[0] <= 0 || a[0] > 1.001 || a[1] < 0 || a[1] > a[0] {
+			t.Errorf("entry %d has invalid amplitudes %v", i, a)
+		}
+	}
+
+	// lobes are symmetric at normal incidence, and widen with roughness
+	for i := 0; i < ltcSize; i++ {
+		m := ltcMatrices[i]
+		if m[0] != 1 || m[1] != 0 || m[2] != 0 {
+			t.Errorf("entry %d is not isotropic: %v", i, m)
+		}
+		if i > 0 && m[3] <= ltcMatrices[i-1][3] {
+			t.Errorf("entry %d is narrower than entry %d", i, i-1)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+// +build ignore
+
+// ltcfit fits linearly transformed cosines to the GGX specular lobe and
+// writes the tables in ltctable.go. Run it with go generate.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+)
+
+// must match ltcSize in ltc.go
+const size = 32
+
+// samples along each dimension when integrating lobes
+const samples = 64
+
+type vec3 [3]float64
+
+func (v vec3) add(u vec3) vec3 {
+	return vec3{v[0] + u[0], v[1] + u[1], v[2] + u[2]}
+}
+
+func (v vec3) scale(s float64) vec3 {
+	return vec3{s * v[0], s * v[1], s * v[2]}
+}
+
+func (v vec3) dot(u vec3) float64 {
+	return v[0]*u[0] + v[1]*u[1] + v[2]*u[2]
+}
+
+func (v vec3) norm() vec3 {
+	return v.scale(1 / math.Sqrt(v.dot(v)))
+}
+
+// mat3 is stored by columns
+type mat3 [3]vec3
+
+func (m *mat3) mul(v vec3) vec3 {
+	return m[0].scale(v[0]).add(m[1].scale(v[1])).add(m[2].scale(v[2]))
+}
+
+func (m *mat3) det() float64 {
+	return m[0][0]*(m[1][1]*m[2][2]-m[2][1]*m[1][2]) -
+		m[1][0]*(m[0][1]*m[2][2]-m[2][1]*m[0][2]) +
+		m[2][0]*(m[0][1]*m[1][2]-m[1][1]*m[0][2])
+}
+
+func (m *mat3) inverse() mat3 {
+	d := m.det()
+	var inv mat3
+	for c := 0; c < 3; c++ {
+		for r := 0; r < 3; r++ {
+			// the cofactor of element (c, r), transposed
+			c1, c2 := (r+1)%3, (r+2)%3
+			r1, r2 := (c+1)%3, (c+2)%3
+			inv[c][r] = (m[c1][r1]*m[c2][r2] - m[c2][r1]*m[c1][r2]) / d
+		}
+	}
+	return inv
+}
+
+// ggx is the GGX lobe with roughness alpha times the cosine,
+// without Fresnel reflection
+type ggx struct {
+	alpha float64
+}
+
+func (b ggx) lambda(cos float64) float64 {
+	tan2 := (1 - cos*cos) / (cos * cos)
+	return (-1 + math.Sqrt(1+b.alpha*b.alpha*tan2)) / 2
+}
+
+// eval returns the lobe and the probability density of sampling l
+func (b ggx) eval(v, l vec3) (value, pdf float64) {
+	if v[2] <= 0 || l[2] <= 0 {
+		return 0, 0
+	}
+	h := v.add(l).norm()
+	if v.dot(h) <= 0 {
+		return 0, 0
+	}
+	a2 := b.alpha * b.alpha
+	d := a2 / (math.Pi * math.Pow(1+(a2-1)*h[2]*h[2], 2))
+	g := 1 / (1 + b.lambda(v[2]) + b.lambda(l[2]))
+	return d * g / (4 * v[2]), d * h[2] / (4 * v.dot(h))
+}
+
+// sample reflects v about a half vector sampled from the distribution
+func (b ggx) sample(v vec3, u1, u2 float64) vec3 {
+	phi := 2 * math.Pi * u1
+	r := b.alpha * math.Sqrt(u2/(1-u2))
+	h := vec3{r * math.Cos(phi), r * math.Sin(phi), 1}.norm()
+	return h.scale(2 * v.dot(h)).add(v.scale(-1))
+}
+
+// moments integrates the lobe, also weighted by (1 - v.h)^5 for Fresnel
+// reflection, and finds its average direction
+func (b ggx) moments(v vec3) (norm, fresnel float64, dir vec3) {
+	for i := 0; i < samples; i++ {
+		for j := 0; j < samples; j++ {
+			l := b.sample(v, (float64(i)+0.5)/samples, (float64(j)+0.5)/samples)
+			value, pdf := b.eval(v, l)
+			if pdf == 0 {
+				continue
+			}
+			weight := value / pdf
+			h := v.add(l).norm()
+			norm += weight
+			fresnel += weight * math.Pow(1-v.dot(h), 5)
+			dir = dir.add(l.scale(weight))
+		}
+	}
+	dir[1] = 0 // the lobe is symmetric about the plane of v and the normal
+	return norm / (samples * samples), fresnel / (samples * samples), dir.norm()
+}
+
+// ltc is a clamped cosine transformed by m = frame * (m11 0 m13; 0 m22 0; 0 0 1)
+type ltc struct {
+	frame         mat3
+	m11, m22, m13 float64
+	m, invM       mat3
+	detM          float64
+}
+
+func (t *ltc) update() {
+	t.m = mat3{
+		t.frame[0].scale(t.m11),
+		t.frame[1].scale(t.m22),
+		t.frame[0].scale(t.m13).add(t.frame[2]),
+	}
+	t.invM = t.m.inverse()
+	t.detM = math.Abs(t.m.det())
+}
+
+// eval returns the normalized distribution in the direction l
+func (t *ltc) eval(l vec3) float64 {
+	original := t.invM.mul(l).norm()
+	transformed := t.m.mul(original)
+	length := math.Sqrt(transformed.dot(transformed))
+	jacobian := t.detM / (length * length * length)
+	return math.Max(original[2], 0) / math.Pi / jacobian
+}
+
+func (t *ltc) sample(u1, u2 float64) vec3 {
+	theta := math.Acos(math.Sqrt(u1))
+	phi := 2 * math.Pi * u2
+	l := vec3{math.Sin(theta) * math.Cos(phi), math.Sin(theta) * math.Sin(phi), math.Cos(theta)}
+	return t.m.mul(l).norm()
+}
+
+// fitError compares the normalized lobe to the distribution, with multiple
+// importance sampling of both
+func fitError(t *ltc, b ggx, v vec3, norm float64) float64 {
+	sum := 0.0
+	for i := 0; i < samples; i++ {
+		for j := 0; j < samples; j++ {
+			u1 := (float64(i) + 0.5) / samples
+			u2 := (float64(j) + 0.5) / samples
+			for _, l := range []vec3{t.sample(u1, u2), b.sample(v, u1, u2)} {
+				value, pdf := b.eval(v, l)
+				fit := t.eval(l)
+				if fit+pdf == 0 {
+					continue
+				}
+				e := math.Abs(value/norm - fit)
+				sum += e * e * e / (fit + pdf)
+			}
+		}
+	}
+	return sum / (samples * samples)
+}
+
+// nelderMead minimizes f from start with the simplex method
+func nelderMead(f func([]float64) float64, start []float64, delta float64) []float64 {
+	n := len(start)
+	points := make([][]float64, n+1)
+	values := make([]float64, n+1)
+	for i := range points {
+		points[i] = append([]float64(nil), start...)
+		if i > 0 {
+			points[i][i-1] += delta
+		}
+		values[i] = f(points[i])
+	}
+
+	along := func(from, to []float64, t float64) []float64 {
+		p := make([]float64, n)
+		for k := range p {
+			p[k] = from[k] + t*(to[k]-from[k])
+		}
+		return p
+	}
+
+	for iter := 0; iter < 200; iter++ {
+		// order the points from best to worst
+		for i := 1; i <= n; i++ {
+			for j := i; j > 0 && values[j] < values[j-1]; j-- {
+				points[j], points[j-1] = points[j-1], points[j]
+				values[j], values[j-1] = values[j-1], values[j]
+			}
+		}
+		if values[n]-values[0] <= 1e-5*math.Abs(values[0]) {
+			break
+		}
+
+		centroid := make([]float64, n)
+		for _, p := range points[:n] {
+			for k := range centroid {
+				centroid[k] += p[k] / float64(n)
+			}
+		}
+
+		worst := points[n]
+		reflected := along(centroid, worst, -1)
+		fr := f(reflected)
+		switch {
+		case fr < values[0]:
+			expanded := along(centroid, worst, -2)
+			if fe := f(expanded); fe < fr {
+				points[n], values[n] = expanded, fe
+			} else {
+				points[n], values[n] = reflected, fr
+			}
+		case fr < values[n-1]:
+			points[n], values[n] = reflected, fr
+		default:
+			contracted := along(centroid, worst, 0.5)
+			if fc := f(contracted); fc < values[n] {
+				points[n], values[n] = contracted, fc
+			} else {
+				// shrink towards the best point
+				for i := 1; i <= n; i++ {
+					points[i] = along(points[0], points[i], 0.5)
+					values[i] = f(points[i])
+				}
+			}
+		}
+	}
+	return points[0]
+}
+
+func main() {
+	matrices := make([][4]float64, size*size)
+	amplitudes := make([][2]float64, size*size)
+
+	// fit from rough to smooth lobes and from normal to grazing views,
+	// starting each fit from the previous one
+	var t ltc
+	for i := size - 1; i >= 0; i-- {
+		roughness := float64(i) / (size - 1)
+		b := ggx{math.Max(roughness*roughness, 0.001)}
+
+		for j := 0; j < size; j++ {
+			s := float64(j) / (size - 1)
+			cosTheta := math.Max(1-s*s, 0.001)
+			v := vec3{math.Sqrt(1 - cosTheta*cosTheta), 0, cosTheta}
+
+			norm, fresnel, dir := b.moments(v)
+
+			isotropic := j == 0
+			if isotropic {
+				t.frame = mat3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+				if i == size-1 {
+					t.m11, t.m22 = 1, 1
+				} else {
+					// the normalized inverse of diag(m11, m11, 1) is diag(1, 1, m11)
+					t.m11, t.m22 = matrices[i+1][3], matrices[i+1][3]
+				}
+				t.m13 = 0
+			} else {
+				t.frame = mat3{{dir[2], 0, -dir[0]}, {0, 1, 0}, dir}
+			}
+			t.update()
+
+			fit := nelderMead(func(p []float64) float64 {
+				t.m11 = math.Max(p[0], 1e-7)
+				t.m22 = math.Max(p[1], 1e-7)
+				t.m13 = p[2]
+				if isotropic {
+					t.m22 = t.m11
+					t.m13 = 0
+				}
+				t.update()
+				return fitError(&t, b, v, norm)
+			}, []float64{t.m11, t.m22, t.m13}, 0.05)
+			t.m11 = math.Max(fit[0], 1e-7)
+			t.m22 = math.Max(fit[1], 1e-7)
+			t.m13 = fit[2]
+			if isotropic {
+				t.m22 = t.m11
+				t.m13 = 0
+			}
+			t.update()
+
+			// normalize the inverse so its middle element is 1
+			inv := t.invM
+			scale := 1 / inv[1][1]
+			matrices[j*size+i] = [4]float64{inv[0][0] * scale, inv[0][2] * scale, inv[2][0] * scale, inv[2][2] * scale}
+			amplitudes[j*size+i] = [2]float64{norm, fresnel}
+		}
+	}
+
+	f, err := os.Create("ltctable.go")
+	if err != nil {
+		panic(err)
+	}
+	w := bufio.NewWriter(f)
+	fmt.Fprintln(w, "// Code generated by ltcfit.go; DO NOT EDIT.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "package render")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `import "github.com/hersle/gl3d/math"`)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "var ltcMatrices = []math.Vec4{")
+	for _, m := range matrices {
+		fmt.Fprintf(w, "\t{%.6g, %.6g, %.6g, %.6g},\n", m[0], m[1], m[2], m[3])
+	}
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "var ltcAmplitudes = []math.Vec4{")
+	for _, a := range amplitudes {
+		fmt.Fprintf(w, "\t{%.6g, %.6g, 0, 0},\n", a[0], a[1])
+	}
+	fmt.Fprintln(w, "}")
+	err = w.Flush()
+	if err == nil {
+		err = f.Close()
+	}
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,173 @@
+package render
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/hersle/gl3d/math"
+	"github.com/hersle/gl3d/utils"
+	"image"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ColorLUT maps colors to graded colors through a Size^3 lattice, with red
+// varying fastest and blue slowest
+type ColorLUT struct {
+	Size int
+	Data []math.Vec3
+}
+
+// NewIdentityColorLUT returns a lookup table that leaves colors unchanged
+func NewIdentityColorLUT(size int) *ColorLUT {
+	var lut ColorLUT
+	lut.Size = size
+	lut.Data = make([]math.Vec3, 0, size*size*size)
+	for b := 0; b < size; b++ {
+		for g := 0; g < size; g++ {
+			for r := 0; r < size; r++ {
+				x := float32(r) / float32(size-1)
+				y := float32(g) / float32(size-1)
+				z := float32(b) / float32(size-1)
+				lut.Data = append(lut.Data, math.Vec3{x, y, z})
+			}
+		}
+	}
+	return &lut
+}
+
+// ReadColorLUT reads a lookup table from a .cube file, or from an image
+// strip of Size slices of Size x Size pixels side by side
+func ReadColorLUT(filename string) (*ColorLUT, error) {
+	switch path.Ext(filename) {
+	case ".cube":
+		return ReadColorLUTCube(filename)
+	default:
+		img, err := utils.ReadImage(filename)
+		if err != nil {
+			return nil, err
+		}
+		return NewColorLUTFromStrip(img)
+	}
+}
+
+// NewColorLUTFromStrip reads slices of increasing blue from left to right,
+// with red increasing to the right and green downwards in each slice
+func NewColorLUTFromStrip(img image.Image) (*ColorLUT, error) {
+	bounds := img.Bounds()
+	size := bounds.Dy()
+	if size < 2 || bounds.Dx() != size*size {
+		return nil, errors.New(fmt.Sprintf("color lookup strip of size %dx%d is not N^2xN", bounds.Dx(), bounds.Dy()))
+	}
+
+	var lut ColorLUT
+	lut.Size = size
+	lut.Data = make([]math.Vec3, 0, size*size*size)
+	for b := 0; b < size; b++ {
+		for g := 0; g < size; g++ {
+			for r := 0; r < size; r++ {
+				cr, cg, cb, _ := img.At(bounds.Min.X+b*size+r, bounds.Min.Y+g).RGBA()
+				color := math.Vec3{float32(cr), float32(cg), float32(cb)}.Scale(1.0 / 0xffff)
+				lut.Data = append(lut.Data, color)
+			}
+		}
+	}
+	return &lut, nil
+}
+
+func ReadColorLUTCube(filename string) (*ColorLUT, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return readColorLUTCube(file, filename)
+}
+
+// readColorLUTCube reads a lookup table in the .cube format, naming it
+// filename in errors
+func readColorLUTCube(r io.Reader, filename string) (*ColorLUT, error) {
+	var lut ColorLUT
+	var err error
+	min := math.Vec3{0, 0, 0}
+	max := math.Vec3{1, 1, 1}
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		switch fields[0] {
+		case "TITLE":
+		case "LUT_3D_SIZE":
+			if len(fields) != 2 {
+				return nil, errors.New(fmt.Sprintf("%s: expected 1 size, got %d", filename, len(fields)-1))
+			}
+			lut.Size, err = strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, err
+			}
+			if lut.Size < 2 || lut.Size > 256 {
+				return nil, errors.New(fmt.Sprintf("%s has invalid size %d", filename, lut.Size))
+			}
+			lut.Data = make([]math.Vec3, 0, lut.Size*lut.Size*lut.Size)
+		case "DOMAIN_MIN":
+			min, err = parseVec3(fields[1:])
+		case "DOMAIN_MAX":
+			max, err = parseVec3(fields[1:])
+		case "LUT_1D_SIZE":
+			return nil, errors.New(fmt.Sprintf("%s has a 1D lookup table", filename))
+		default:
+			var color math.Vec3
+			color, err = parseVec3(fields)
+			lut.Data = append(lut.Data, color)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	if lut.Size < 2 || len(lut.Data) != lut.Size*lut.Size*lut.Size {
+		return nil, errors.New(fmt.Sprintf("%s has %d entries for size %d", filename, len(lut.Data), lut.Size))
+	}
+
+	for j := 0; j < 3; j++ {
+		if max[j] <= min[j] {
+			return nil, errors.New(fmt.Sprintf("%s has an empty domain from %v to %v", filename, min, max))
+		}
+	}
+
+	// normalize to [0, 1]
+	for i := range lut.Data {
+		for j := 0; j < 3; j++ {
+			lut.Data[i][j] = (lut.Data[i][j] - min[j]) / (max[j] - min[j])
+		}
+	}
+
+	return &lut, nil
+}
+
+func parseVec3(fields []string) (math.Vec3, error) {
+	var v math.Vec3
+	if len(fields) != 3 {
+		return v, errors.New(fmt.Sprintf("expected 3 numbers, got %d", len(fields)))
+	}
+	for i := 0; i < 3; i++ {
+		f, err := strconv.ParseFloat(fields[i], 32)
+		if err != nil {
+			return v, err
+		}
+		v[i] = float32(f)
+	}
+	return v, nil
+}
@@ -0,0 +1,132 @@
+package render
+
+import (
+	"github.com/hersle/gl3d/math"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const identityCube = `TITLE "identity"
+# scaled to a domain of [0, 2]
+LUT_3D_SIZE 2
+DOMAIN_MIN 0 0 0
+DOMAIN_MAX 2 2 2
+0 0 0
+2 0 0
+0 2 0
+2 2 0
+0 0 2
+2 0 2
+0 2 2
+2 2 2
+`
+
+func equalColorLUTs(a, b *ColorLUT) bool {
+	if a.Size != b.Size || len(a.Data) != len(b.Data) {
+		return false
+	}
+	for i := range a.Data {
+		for j := 0; j < 3; j++ {
+			if !approxEqual(a.Data[i][j], b.Data[i][j], 1e-6) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestReadColorLUTCube(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		valid bool
+	}{
+		{"valid", identityCube, true},
+		{"short", strings.TrimSuffix(identityCube, "2 2 2\n"), false},
+		{"long", identityCube + "1 1 1\n", false},
+		{"1D", "LUT_1D_SIZE 2\n0 0 0\n1 1 1\n", false},
+		{"no size", "0 0 0\n", false},
+		{"missing size", strings.Replace(identityCube, "LUT_3D_SIZE 2", "LUT_3D_SIZE", 1), false},
+		{"extra size", strings.Replace(identityCube, "LUT_3D_SIZE 2", "LUT_3D_SIZE 2 2", 1), false},
+		{"invalid size", strings.Replace(identityCube, "LUT_3D_SIZE 2", "LUT_3D_SIZE two", 1), false},
+		{"too small", strings.Replace(identityCube, "LUT_3D_SIZE 2", "LUT_3D_SIZE 1", 1), false},
+		{"negative size", strings.Replace(identityCube, "LUT_3D_SIZE 2", "LUT_3D_SIZE -3", 1), false},
+		{"short entry", strings.Replace(identityCube, "2 2 0\n", "2 2\n", 1), false},
+		{"malformed entry", strings.Replace(identityCube, "2 2 0\n", "2 x 0\n", 1), false},
+		{"short domain", strings.Replace(identityCube, "DOMAIN_MAX 2 2 2", "DOMAIN_MAX 2 2", 1), false},
+		{"empty domain", strings.Replace(identityCube, "DOMAIN_MAX 2 2 2", "DOMAIN_MAX 2 0 2", 1), false},
+	}
+	for _, test := range tests {
+		lut, err := readColorLUTCube(strings.NewReader(test.src), test.name)
+		if test.valid {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", test.name, err)
+			} else if !equalColorLUTs(lut, NewIdentityColorLUT(2)) {
+				t.Errorf("%s: got %v, expected the identity", test.name, lut.Data)
+			}
+		} else if err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
+
+func TestReadColorLUTCubeFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "identity.cube")
+	err := os.WriteFile(filename, []byte(identityCube), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lut, err := ReadColorLUT(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalColorLUTs(lut, NewIdentityColorLUT(2)) {
+		t.Errorf("got %v, expected the identity", lut.Data)
+	}
+
+	_, err = ReadColorLUT(filepath.Join(t.TempDir(), "missing.cube"))
+	if err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
+
+func TestNewColorLUTFromStrip(t *testing.T) {
+	// identity with blue slices side by side, except one darker red entry
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for b := 0; b < 2; b++ {
+		for g := 0; g < 2; g++ {
+			for r := 0; r < 2; r++ {
+				img.Set(b*2+r, g, color.RGBA{uint8(255 * r), uint8(255 * g), uint8(255 * b), 255})
+			}
+		}
+	}
+	img.Set(1, 0, color.RGBA{51, 0, 0, 255})
+
+	lut, err := NewColorLUTFromStrip(img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := NewIdentityColorLUT(2)
+	expected.Data[1] = math.Vec3{0.2, 0, 0}
+	if !equalColorLUTs(lut, expected) {
+		t.Errorf("got %v, expected %v", lut.Data, expected.Data)
+	}
+
+	// strips must be N^2 x N for N >= 2
+	for _, rect := range []image.Rectangle{
+		image.Rect(0, 0, 1, 1),
+		image.Rect(0, 0, 3, 2),
+		image.Rect(0, 0, 4, 3),
+		image.Rect(0, 0, 0, 0),
+	} {
+		_, err := NewColorLUTFromStrip(image.NewRGBA(rect))
+		if err == nil {
+			t.Errorf("expected an error for a %dx%d strip", rect.Dx(), rect.Dy())
+		}
+	}
+}
@@ -11,30 +11,55 @@ import (
 	"github.com/hersle/gl3d/utils"
 	"image"
 	"fmt"
+	"log"
+	"math/rand"
 	gomath "math"
 )
 
 type MeshRenderer struct {
-	depthProg *MeshProgram
-	ambientProg *MeshProgram
+	meshProgs *graphics.ProgramCache
+	meshProgsByProg map[*graphics.Program]*MeshProgram
+	meshProgErrs map[error]bool // build errors that have been logged
 	ssaoProg *ssaoProgram
 	ssaoBlurProg *ssaoBlurProgram
-	pointLitProg *MeshProgram
-	spotLitProg *MeshProgram
-	dirLitProg *MeshProgram
 
 	shadowMapRenderer *ShadowMapRenderer
+	occlusionCuller *occlusionCuller
+	lightClusters *lightClusters
 
 	resources *meshResourceManager
 
 	renderOpts *graphics.RenderOptions
 
+	colorTarget graphics.RenderTarget
+	depthTarget graphics.RenderTarget
+
 	pointLightMesh *object.Mesh
 	spotLightMesh *object.Mesh
+	rectangleLightMesh *object.Mesh
+	diskLightMesh *object.Mesh
+
+	// linearly transformed cosines for area lights
+	ltcMatrixMap *graphics.Texture2D
+	ltcAmplitudeMap *graphics.Texture2D
 
 	normalMatrices []math.Mat4
 
 	cullCache []bool
+	depthCache []float32 // view space depth of submeshes
+
+	queue renderQueue
+
+	// motion since the previous frame, written by the depth pass if set
+	velocityTarget *graphics.Texture2D
+	projViewMat *math.Mat4 // without jitter, tracked by Renderer
+	previousProjViewMat *math.Mat4
+	previousWorldMatrices map[*object.Mesh]math.Mat4
+	nextWorldMatrices     map[*object.Mesh]math.Mat4 // reused to forget removed meshes
+
+	// view space normals and reflectivity, written by the surface pass if set
+	surfaceTarget *graphics.Texture2D
+	normalTarget *graphics.Texture2D // the same without normal mapping
 
 	ShadowKernelSize int
 
@@ -45,14 +70,26 @@ type MeshRenderer struct {
 	MaterialNormalEnabled bool
 	ShadowsEnabled bool
 	Wireframe bool
+	OcclusionCulling bool
+	ClusteredShading bool
+	Toon bool // quantize lighting into bands
+	ToonBands int
 
 	AmbientOcclusion bool
+	AmbientOcclusionRadius float32 // in view space
+	AmbientOcclusionSamples int
+	AmbientOcclusionBias float32
+	AmbientOcclusionPower float32
+	AmbientOcclusionHalfResolution bool
 	randomDirectionMap *graphics.Texture2D
+	aoKernel []math.Vec3
 	aoMap *graphics.Texture2D
+	aoMapExtra *graphics.Texture2D // for blurring
 	blurredAoMap *graphics.Texture2D
 }
 
 type meshResourceManager struct {
+	ids map[interface{}]int
 	vbos map[*object.Vertex]*graphics.VertexBuffer
 	ibos map[*int32]*graphics.IndexBuffer
 
@@ -96,6 +133,10 @@ type MeshProgram struct {
 	ProjectionMatrix *graphics.Uniform
 	NormalMatrix     *graphics.Uniform
 
+	PreviousModelMatrix          *graphics.Uniform
+	ProjectionViewMatrix         *graphics.Uniform
+	PreviousProjectionViewMatrix *graphics.Uniform
+
 	MaterialAmbient     *graphics.Uniform
 	MaterialAmbientMap  *graphics.Uniform
 	MaterialDiffuse     *graphics.Uniform
@@ -113,14 +154,35 @@ type MeshProgram struct {
 	LightDirection         *graphics.Uniform
 	LightColor             *graphics.Uniform
 	LightAttenuation       *graphics.Uniform
+	LightRange             *graphics.Uniform
 	LightCosAngle          *graphics.Uniform
+	LightCosInnerAngle     *graphics.Uniform
+	LightCookie            *graphics.Uniform
+	CookieProjectionViewMatrix *graphics.Uniform
+
+	LightPoints            []*graphics.Uniform
+	LightPointCount        *graphics.Uniform
+	LightNormal            *graphics.Uniform
+	LightTwoSided          *graphics.Uniform
+	LtcMatrixMap           *graphics.Uniform
+	LtcAmplitudeMap        *graphics.Uniform
 
 	ShadowProjectionViewMatrix *graphics.Uniform
 	ShadowMap              *graphics.Uniform
 	ShadowFar              *graphics.Uniform
 	ShadowKernelSize       *graphics.Uniform
 
+	ToonBands *graphics.Uniform
+
 	AoMap *graphics.Uniform
+
+	LightBuffer      *graphics.StorageBlock
+	ClusterBuffer    *graphics.StorageBlock
+	LightIndexBuffer *graphics.StorageBlock
+	ClusterNear      *graphics.Uniform
+	ClusterFar       *graphics.Uniform
+	ViewportWidth    *graphics.Uniform
+	ViewportHeight   *graphics.Uniform
 }
 
 type ShadowMapProgram struct {
@@ -146,12 +208,14 @@ type ssaoProgram struct {
 	Position *graphics.Input
 	Color               *graphics.Output
 	DepthMap            *graphics.Uniform
-	DepthMapWidth       *graphics.Uniform
-	DepthMapHeight      *graphics.Uniform
 	ProjectionMatrix *graphics.Uniform
 	InvProjectionMatrix *graphics.Uniform
 	Directions          []*graphics.Uniform
 	DirectionMap        *graphics.Uniform
+	SampleCount         *graphics.Uniform
+	Radius              *graphics.Uniform
+	Bias                *graphics.Uniform
+	Power               *graphics.Uniform
 }
 
 type ssaoBlurProgram struct {
@@ -159,24 +223,30 @@ type ssaoBlurProgram struct {
 
 	Color *graphics.Output
 	aoMap *graphics.Uniform
-	aoMapWidth *graphics.Uniform
-	aoMapHeight *graphics.Uniform
+	depthMap *graphics.Uniform
+	invProjectionMatrix *graphics.Uniform
+	direction *graphics.Uniform
 }
 
+// must match the constant in the ssao shader
+const ssaoMaxSamples = 64
+
 func NewMeshRenderer() (*MeshRenderer, error) {
 	var r MeshRenderer
 
-	r.depthProg = NewMeshProgram("DEPTH")
+	r.meshProgs = graphics.NewProgramCache(shaderFS(), "meshvshadertemplate.glsl", "meshfshadertemplate.glsl", "")
+	r.meshProgsByProg = make(map[*graphics.Program]*MeshProgram)
+	r.meshProgErrs = make(map[error]bool)
 	r.ssaoProg = NewSSAOProgram()
 	r.ssaoBlurProg = NewSSAOBlurProgram()
-	r.ambientProg = NewMeshProgram("AMBIENT")
-	r.pointLitProg = NewMeshProgram("POINT", "SHADOW", "PCF")
-	r.spotLitProg = NewMeshProgram("SPOT", "SHADOW", "PCF")
-	r.dirLitProg = NewMeshProgram("DIR", "SHADOW", "PCF")
 
 	r.resources = newMeshResourceManager()
 
 	r.shadowMapRenderer = NewShadowMapRenderer(r.resources) // share resources
+	r.occlusionCuller = newOcclusionCuller()
+	r.lightClusters = newLightClusters()
+	r.previousWorldMatrices = make(map[*object.Mesh]math.Mat4)
+	r.nextWorldMatrices = make(map[*object.Mesh]math.Mat4)
 
 	r.renderOpts = graphics.NewRenderOptions()
 
@@ -188,6 +258,14 @@ func NewMeshRenderer() (*MeshRenderer, error) {
 	geo = object.NewCone(math.Vec3{0, 0, -1}, math.Vec3{0, 0, 0}, 0.5).Geometry(6)
 	r.spotLightMesh = object.NewMesh(geo, mtl)
 
+	// unit sized, and scaled to each light
+	geo = object.NewPlane(math.Vec3{0, 0, 0}, math.Vec3{0, 0, 1}).Geometry(1)
+	r.rectangleLightMesh = object.NewMesh(geo, mtl)
+	geo = object.NewCircle(0.5, math.Vec3{0, 0, 0}, math.Vec3{0, 0, 1}).Geometry(maxAreaLightPoints)
+	r.diskLightMesh = object.NewMesh(geo, mtl)
+
+	r.ltcMatrixMap, r.ltcAmplitudeMap = newLTCMaps()
+
 	r.MaterialAmbientEnabled = true
 	r.MaterialDiffuseEnabled = true
 	r.MaterialSpecularEnabled = true
@@ -195,6 +273,13 @@ func NewMeshRenderer() (*MeshRenderer, error) {
 	r.MaterialNormalEnabled = true
 	r.ShadowsEnabled = true
 	r.AmbientOcclusion = true
+	r.AmbientOcclusionRadius = 0.5
+	r.AmbientOcclusionSamples = 16
+	r.AmbientOcclusionBias = 0.025
+	r.AmbientOcclusionPower = 1.5
+	r.OcclusionCulling = true
+	r.ClusteredShading = true
+	r.ToonBands = 3
 
 	w := 1920 / 1
 	h := 1080 / 1
@@ -205,9 +290,6 @@ func NewMeshRenderer() (*MeshRenderer, error) {
 	}
 	r.randomDirectionMap.SetData(0, 0, w, h, directions)
 
-	r.aoMap = graphics.NewColorTexture2D(graphics.LinearFilter, graphics.RepeatWrap, 1920 / 1, 1080 / 1, 1, 8, false, false)
-	r.blurredAoMap = graphics.NewColorTexture2D(graphics.LinearFilter, graphics.RepeatWrap, 1920 / 1, 1080 / 1, 1, 8, false, false)
-
 	return &r, nil
 }
 
@@ -233,11 +315,15 @@ func NewShadowMapRenderer(resources *meshResourceManager) *ShadowMapRenderer {
 }
 
 func NewMeshProgram(defines ...string) *MeshProgram {
+	vFile := "meshvshadertemplate.glsl"
+	fFile := "meshfshadertemplate.glsl"
+	return newMeshProgram(readProgram(vFile, fFile, "", defines...))
+}
+
+func newMeshProgram(prog *graphics.Program) *MeshProgram {
 	var sp MeshProgram
 
-	vFile := "render/shaders/meshvshadertemplate.glsl" // TODO: make independent from executable directory
-	fFile := "render/shaders/meshfshadertemplate.glsl" // TODO: make independent from executable directory
-	sp.Program = graphics.ReadProgram(vFile, fFile, "", defines...)
+	sp.Program = prog
 
 	sp.Position = sp.InputByName("position")
 	sp.TexCoord = sp.InputByName("texCoordV")
@@ -252,6 +338,10 @@ func NewMeshProgram(defines ...string) *MeshProgram {
 	sp.ProjectionMatrix = sp.UniformByName("projectionMatrix")
 	sp.NormalMatrix = sp.UniformByName("normalMatrix")
 
+	sp.PreviousModelMatrix = sp.UniformByName("previousModelMatrix")
+	sp.ProjectionViewMatrix = sp.UniformByName("projectionViewMatrix")
+	sp.PreviousProjectionViewMatrix = sp.UniformByName("previousProjectionViewMatrix")
+
 	sp.MaterialAmbient = sp.UniformByName("materialAmbient")
 	sp.MaterialAmbientMap = sp.UniformByName("materialAmbientMap")
 	sp.MaterialDiffuse = sp.UniformByName("materialDiffuse")
@@ -269,25 +359,49 @@ func NewMeshProgram(defines ...string) *MeshProgram {
 	sp.LightDirection = sp.UniformByName("lightDirection")
 	sp.LightColor = sp.UniformByName("lightColor")
 	sp.LightAttenuation = sp.UniformByName("lightAttenuation")
+	sp.LightRange = sp.UniformByName("lightRange")
 	sp.LightCosAngle = sp.UniformByName("lightCosAng")
+	sp.LightCosInnerAngle = sp.UniformByName("lightCosInnerAng")
+	sp.LightCookie = sp.UniformByName("lightCookie")
+	sp.CookieProjectionViewMatrix = sp.UniformByName("cookieProjectionViewMatrix")
+
+	sp.LightPoints = make([]*graphics.Uniform, maxAreaLightPoints)
+	for i := range sp.LightPoints {
+		sp.LightPoints[i] = sp.UniformByName(fmt.Sprintf("lightPoints[%d]", i))
+	}
+	sp.LightPointCount = sp.UniformByName("lightPointCount")
+	sp.LightNormal = sp.UniformByName("lightNormal")
+	sp.LightTwoSided = sp.UniformByName("lightTwoSided")
+	sp.LtcMatrixMap = sp.UniformByName("ltcMatrixMap")
+	sp.LtcAmplitudeMap = sp.UniformByName("ltcAmplitudeMap")
 
 	sp.ShadowProjectionViewMatrix = sp.UniformByName("shadowProjectionViewMatrix")
 	sp.ShadowMap = sp.UniformByName("shadowMap")
 	sp.ShadowFar = sp.UniformByName("lightFar")
 	sp.ShadowKernelSize = sp.UniformByName("kernelSize")
 
+	sp.ToonBands = sp.UniformByName("toonBands")
+
 	sp.AoMap = sp.UniformByName("aoMap")
 
+	sp.LightBuffer = sp.StorageBlockByName("lightBuffer")
+	sp.ClusterBuffer = sp.StorageBlockByName("clusterBuffer")
+	sp.LightIndexBuffer = sp.StorageBlockByName("lightIndexBuffer")
+	sp.ClusterNear = sp.UniformByName("clusterNear")
+	sp.ClusterFar = sp.UniformByName("clusterFar")
+	sp.ViewportWidth = sp.UniformByName("viewportWidth")
+	sp.ViewportHeight = sp.UniformByName("viewportHeight")
+
 	return &sp
 }
 
 func NewShadowMapProgram(defines ...string) *ShadowMapProgram {
 	var sp ShadowMapProgram
 
-	vFile := "render/shaders/shadowmapvshadertemplate.glsl" // TODO: make independent from executable directory
-	fFile := "render/shaders/shadowmapfshadertemplate.glsl" // TODO: make independent from executable directory
-	gFile := "render/shaders/shadowmapgshadertemplate.glsl" // TODO: make independent from executable directory
-	sp.Program = graphics.ReadProgram(vFile, fFile, gFile, defines...)
+	vFile := "shadowmapvshadertemplate.glsl"
+	fFile := "shadowmapfshadertemplate.glsl"
+	gFile := "shadowmapgshadertemplate.glsl"
+	sp.Program = readProgram(vFile, fFile, gFile, defines...)
 
 	sp.ModelMatrix = sp.UniformByName("modelMatrix")
 	sp.ViewMatrix = sp.UniformByName("viewMatrix")
@@ -310,18 +424,26 @@ func NewShadowMapProgram(defines ...string) *ShadowMapProgram {
 func NewSSAOProgram() *ssaoProgram {
 	var sp ssaoProgram
 
-	vFile := "render/shaders/ssaovshader.glsl" // TODO: make independent from executable directory
-	fFile := "render/shaders/ssaofshader.glsl" // TODO: make independent from executable directory
-	sp.Program = graphics.ReadProgram(vFile, fFile, "")
+	vFile := "ssaovshader.glsl"
+	fFile := "ssaofshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
 
 	sp.Position = sp.InputByName("position")
 	sp.Color = sp.OutputColorByName("fragColor")
 	sp.DepthMap = sp.UniformByName("depthMap")
-	sp.DepthMapWidth = sp.UniformByName("depthMapWidth")
-	sp.DepthMapHeight = sp.UniformByName("depthMapHeight")
 	sp.ProjectionMatrix = sp.UniformByName("projectionMatrix")
 	sp.InvProjectionMatrix = sp.UniformByName("invProjectionMatrix")
 	sp.DirectionMap = sp.UniformByName("directionMap")
+	sp.SampleCount = sp.UniformByName("sampleCount")
+	sp.Radius = sp.UniformByName("radius")
+	sp.Bias = sp.UniformByName("bias")
+	sp.Power = sp.UniformByName("power")
+
+	sp.Directions = make([]*graphics.Uniform, ssaoMaxSamples)
+	for i := 0; i < ssaoMaxSamples; i++ {
+		name := fmt.Sprintf("directions[%d]", i)
+		sp.Directions[i] = sp.UniformByName(name)
+	}
 
 	return &sp
 }
@@ -329,31 +451,35 @@ func NewSSAOProgram() *ssaoProgram {
 func NewSSAOBlurProgram() *ssaoBlurProgram {
 	var sp ssaoBlurProgram
 
-	vFile := "render/shaders/ssaoblurvshader.glsl" // TODO: make independent from executable directory
-	fFile := "render/shaders/ssaoblurfshader.glsl" // TODO: make independent from executable directory
-	sp.Program = graphics.ReadProgram(vFile, fFile, "")
+	vFile := "ssaoblurvshader.glsl"
+	fFile := "ssaoblurfshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
 
 	sp.Color = sp.OutputColorByName("fragColor")
 	sp.aoMap = sp.UniformByName("aoMap")
-	sp.aoMapWidth = sp.UniformByName("aoMapWidth")
-	sp.aoMapHeight = sp.UniformByName("aoMapHeight")
+	sp.depthMap = sp.UniformByName("depthMap")
+	sp.invProjectionMatrix = sp.UniformByName("invProjectionMatrix")
+	sp.direction = sp.UniformByName("direction")
 
 	return &sp
 }
 
 func (r *MeshRenderer) Render(s *scene.Scene, c camera.Camera, colorTexture, depthTexture *graphics.Texture2D) {
+	r.render(s, c, colorTexture, depthTexture, depthTexture, nil)
+}
+
+// RenderMultisample renders to multisampled targets, resolving the depth to
+// depthTexture for the effects that read it
+func (r *MeshRenderer) RenderMultisample(s *scene.Scene, c camera.Camera, colorTarget, depthTarget *graphics.Texture2DMultisample, depthTexture *graphics.Texture2D) {
+	r.render(s, c, colorTarget, depthTarget, depthTexture, depthTarget)
+}
+
+func (r *MeshRenderer) render(s *scene.Scene, c camera.Camera, colorTarget, depthTarget graphics.RenderTarget, depthTexture *graphics.Texture2D, multisampleDepth *graphics.Texture2DMultisample) {
 	r.renderOpts.Culling = graphics.BackCulling
 	r.renderOpts.Primitive = graphics.Triangles
 
-	r.depthProg.Depth.Set(depthTexture)
-	r.ambientProg.Color.Set(colorTexture)
-	r.pointLitProg.Color.Set(colorTexture)
-	r.spotLitProg.Color.Set(colorTexture)
-	r.dirLitProg.Color.Set(colorTexture)
-	r.ambientProg.Depth.Set(depthTexture)
-	r.pointLitProg.Depth.Set(depthTexture)
-	r.spotLitProg.Depth.Set(depthTexture)
-	r.dirLitProg.Depth.Set(depthTexture)
+	r.colorTarget = colorTarget
+	r.depthTarget = depthTarget
 
 	if r.Wireframe {
 		r.renderOpts.Primitive = graphics.TriangleOutlines
@@ -362,11 +488,123 @@ func (r *MeshRenderer) Render(s *scene.Scene, c camera.Camera, colorTexture, dep
 	}
 
 	r.preparationPass(s, c)
+
+	// cull the same submeshes in all passes, since later passes only draw
+	// where the depth pass did
+	if r.OcclusionCulling {
+		r.occlusionCuller.cull(s, c, r.cullCache)
+	} else {
+		r.occlusionCuller.reset()
+	}
+
+	graphics.BeginPass("shadow")
 	r.shadowPass(s)
+	graphics.EndPass()
+
+	graphics.BeginPass("depth")
 	r.depthPass(s, c)
+	graphics.EndPass()
+
+	if r.OcclusionCulling {
+		graphics.BeginPass("occlusion")
+		r.occlusionCuller.test(c, r.depthTarget)
+		graphics.EndPass()
+	}
+
+	if multisampleDepth != nil {
+		multisampleDepth.Resolve(depthTexture)
+	}
+
+	if r.surfaceTarget != nil || r.normalTarget != nil {
+		graphics.BeginPass("surface")
+		r.surfacePass(s, c)
+		graphics.EndPass()
+	}
+
+	graphics.BeginPass("ssao")
 	r.ssaoPass(depthTexture, c)
+	graphics.EndPass()
+
+	graphics.BeginPass("ambient")
 	r.ambientPass(s, c)
+	graphics.EndPass()
+
+	graphics.BeginPass("light")
 	r.lightPass(s, c)
+	graphics.EndPass()
+
+	r.rememberMotion(s)
+}
+
+// rememberMotion saves the transformations of this frame for computing
+// velocities in the next, also when they are not written this frame
+func (r *MeshRenderer) rememberMotion(s *scene.Scene) {
+	// only keep meshes that are still in the scene
+	for m := range r.nextWorldMatrices {
+		delete(r.nextWorldMatrices, m)
+	}
+	for _, m := range s.Meshes {
+		r.nextWorldMatrices[m] = *m.WorldMatrix()
+	}
+	r.previousWorldMatrices, r.nextWorldMatrices = r.nextWorldMatrices, r.previousWorldMatrices
+}
+
+func (r *MeshRenderer) previousWorldMatrix(m *object.Mesh) *math.Mat4 {
+	mat, found := r.previousWorldMatrices[m]
+	if !found {
+		return m.WorldMatrix()
+	}
+	return &mat
+}
+
+// meshProgram returns the mesh shader permutation with the given defines,
+// compiling it the first time it is requested, or nil and logs the error
+// once if it does not build
+func (r *MeshRenderer) meshProgram(defines ...string) *MeshProgram {
+	prog, err := r.meshProgs.Program(defines...)
+	if err != nil {
+		if !r.meshProgErrs[err] {
+			log.Print("skipping mesh shader ", defines, ": ", err)
+			r.meshProgErrs[err] = true
+		}
+		return nil
+	}
+	sp, found := r.meshProgsByProg[prog]
+	if !found {
+		sp = newMeshProgram(prog)
+		r.meshProgsByProg[prog] = sp
+	}
+	return sp
+}
+
+func (r *MeshRenderer) setTargets(sp *MeshProgram) {
+	sp.Color.Set(r.colorTarget)
+	sp.Depth.Set(r.depthTarget)
+}
+
+func (r *MeshRenderer) lightProgram(lightType string, shadows bool) *MeshProgram {
+	defines := []string{lightType}
+	if shadows {
+		defines = append(defines, "SHADOW", "PCF")
+	}
+	if r.MaterialNormalEnabled {
+		defines = append(defines, "NORMALMAP")
+	}
+	if r.Toon {
+		defines = append(defines, "TOON")
+	}
+	sp := r.meshProgram(defines...)
+	if sp == nil {
+		return nil
+	}
+	r.setTargets(sp)
+	sp.ShadowKernelSize.Set(r.ShadowKernelSize)
+	bands := r.ToonBands
+	if bands < 1 {
+		bands = 1
+	}
+	sp.ToonBands.Set(bands)
+	return sp
 }
 
 func (r *MeshRenderer) ssaoPass(depthMap *graphics.Texture2D, c camera.Camera) {
@@ -374,12 +612,17 @@ func (r *MeshRenderer) ssaoPass(depthMap *graphics.Texture2D, c camera.Camera) {
 		return
 	}
 
+	w, h := depthMap.Width(), depthMap.Height()
+	if r.AmbientOcclusionHalfResolution {
+		w, h = w/2, h/2
+	}
+	r.makeAoMaps(w, h)
+	r.makeAoKernel(r.AmbientOcclusionSamples)
+
 	r.renderOpts.Primitive = graphics.TriangleFan
 	r.renderOpts.Blending = graphics.NoBlending
 	r.ssaoProg.Color.Set(r.aoMap)
 	r.ssaoProg.DepthMap.Set(depthMap)
-	r.ssaoProg.DepthMapWidth.Set(depthMap.Width())
-	r.ssaoProg.DepthMapHeight.Set(depthMap.Height())
 
 	var mat math.Mat4
 	mat.Identity()
@@ -388,31 +631,79 @@ func (r *MeshRenderer) ssaoPass(depthMap *graphics.Texture2D, c camera.Camera) {
 	r.ssaoProg.InvProjectionMatrix.Set(&mat)
 	r.ssaoProg.ProjectionMatrix.Set(c.ProjectionMatrix())
 	r.ssaoProg.DirectionMap.Set(r.randomDirectionMap)
+	r.ssaoProg.SampleCount.Set(len(r.aoKernel))
+	r.ssaoProg.Radius.Set(r.AmbientOcclusionRadius)
+	r.ssaoProg.Bias.Set(r.AmbientOcclusionBias)
+	r.ssaoProg.Power.Set(r.AmbientOcclusionPower)
 
 	r.ssaoProg.Render(4, r.renderOpts)
 
-	r.blurAoMap()
+	r.blurAoMap(depthMap, &mat)
 }
 
-func (r *MeshRenderer) blurAoMap() {
-	if !r.AmbientOcclusion {
+// makeAoKernel uploads sample offsets in a unit hemisphere, denser near the
+// center where occluders matter more, if the sample count changed
+func (r *MeshRenderer) makeAoKernel(n int) {
+	if n < 1 {
+		n = 1
+	}
+	if n > ssaoMaxSamples {
+		n = ssaoMaxSamples
+	}
+	if len(r.aoKernel) == n {
 		return
 	}
 
-	r.ssaoBlurProg.aoMap.Set(r.aoMap)
-	r.ssaoBlurProg.aoMapWidth.Set(r.aoMap.Width())
-	r.ssaoBlurProg.aoMapHeight.Set(r.aoMap.Height())
-	r.ssaoBlurProg.Color.Set(r.blurredAoMap)
+	r.aoKernel = r.aoKernel[:0]
+	for i := 0; i < n; i++ {
+		dir := utils.RandomDirection()
+		if dir.Z() < 0 {
+			dir[2] = -dir[2]
+		}
+		t := float32(i) / float32(n)
+		scale := 0.1 + 0.9*t*t
+		dir = dir.Scale(scale * rand.Float32())
+		r.aoKernel = append(r.aoKernel, dir)
+		r.ssaoProg.Directions[i].Set(dir)
+	}
+}
+
+func (r *MeshRenderer) makeAoMaps(width, height int) {
+	if r.aoMap != nil && r.aoMap.Width() == width && r.aoMap.Height() == height {
+		return
+	}
 
+	r.aoMap = graphics.NewColorTexture2D(graphics.LinearFilter, graphics.EdgeClampWrap, width, height, 1, 8, false, false)
+	r.aoMapExtra = graphics.NewColorTexture2D(graphics.LinearFilter, graphics.EdgeClampWrap, width, height, 1, 8, false, false)
+	r.blurredAoMap = graphics.NewColorTexture2D(graphics.LinearFilter, graphics.EdgeClampWrap, width, height, 1, 8, false, false)
+}
+
+// blurAoMap blurs the noise from the random rotations horizontally and
+// then vertically, keeping edges in the depth map sharp
+func (r *MeshRenderer) blurAoMap(depthMap *graphics.Texture2D, invProjMat *math.Mat4) {
 	var opts graphics.RenderOptions
 	opts.Primitive = graphics.TriangleFan
+
+	r.ssaoBlurProg.depthMap.Set(depthMap)
+	r.ssaoBlurProg.invProjectionMatrix.Set(invProjMat)
+
+	r.ssaoBlurProg.aoMap.Set(r.aoMap)
+	r.ssaoBlurProg.direction.Set(math.Vec2{1 / float32(r.aoMap.Width()), 0})
+	r.ssaoBlurProg.Color.Set(r.aoMapExtra)
+	r.ssaoBlurProg.Render(4, &opts)
+
+	r.ssaoBlurProg.aoMap.Set(r.aoMapExtra)
+	r.ssaoBlurProg.direction.Set(math.Vec2{0, 1 / float32(r.aoMap.Height())})
+	r.ssaoBlurProg.Color.Set(r.blurredAoMap)
 	r.ssaoBlurProg.Render(4, &opts)
 }
 
 func (r *MeshRenderer) preparationPass(s *scene.Scene, c camera.Camera) {
+	r.resources.resetIDs()
+
 	// precalculate normal matrices for use in multiple rendering passes
-	if len(r.normalMatrices) > len(s.Meshes) {
-		 r.normalMatrices = r.normalMatrices[:len(s.Meshes)]
+	if cap(r.normalMatrices) >= len(s.Meshes) {
+		r.normalMatrices = r.normalMatrices[:len(s.Meshes)]
 	} else {
 		r.normalMatrices = make([]math.Mat4, len(s.Meshes))
 	}
@@ -428,52 +719,119 @@ func (r *MeshRenderer) preparationPass(s *scene.Scene, c camera.Camera) {
 	}
 
 	// precalculate culling for use in multiple rendering passes
-	if len(r.cullCache) > subMeshCount {
+	if cap(r.cullCache) >= subMeshCount {
 		r.cullCache = r.cullCache[:subMeshCount]
 	} else {
 		r.cullCache = make([]bool, subMeshCount)
 	}
+	if cap(r.depthCache) >= subMeshCount {
+		r.depthCache = r.depthCache[:subMeshCount]
+	} else {
+		r.depthCache = make([]float32, subMeshCount)
+	}
 	i := 0
 	for _, m := range s.Meshes {
 		for _, sm := range m.SubMeshes {
 			r.cullCache[i] = c.Cull(sm)
+			if !r.cullCache[i] {
+				center := sm.BoundingSphere().Center
+				r.depthCache[i] = -center.Vec4(1).Transform(c.ViewMatrix()).Z()
+			}
 			i++
 		}
 	}
-
-	r.ambientProg.ShadowKernelSize.Set(r.ShadowKernelSize)
-	r.pointLitProg.ShadowKernelSize.Set(r.ShadowKernelSize)
-	r.spotLitProg.ShadowKernelSize.Set(r.ShadowKernelSize)
-	r.dirLitProg.ShadowKernelSize.Set(r.ShadowKernelSize)
 }
 
 func (r *MeshRenderer) depthPass(s *scene.Scene, c camera.Camera) {
 	r.renderOpts.Blending = graphics.NoBlending
 	r.renderOpts.DepthTest = graphics.LessDepthTest
 
-	r.setCamera(r.depthProg, c)
-	r.renderMeshes(s, c, r.depthProg)
+	// only attach depth, since the depth shader leaves the color undefined
+	// unless it writes velocities
+	var depthProg *MeshProgram
+	if r.velocityTarget != nil {
+		depthProg = r.meshProgram("DEPTH", "VELOCITY")
+		if depthProg != nil {
+			depthProg.Color.Set(r.velocityTarget)
+			depthProg.ProjectionViewMatrix.Set(r.projViewMat)
+			depthProg.PreviousProjectionViewMatrix.Set(r.previousProjViewMat)
+		}
+	} else {
+		depthProg = r.meshProgram("DEPTH")
+	}
+	if depthProg != nil {
+		depthProg.Depth.Set(r.depthTarget)
+		r.setCamera(depthProg, c)
+		r.renderMeshes(s, c, depthProg, frontToBackOrder, nil)
+	}
+
+	ambientProg := r.meshProgram("AMBIENT")
+	if ambientProg == nil {
+		return
+	}
+	r.setTargets(ambientProg)
 
 	for _, l := range s.PointLights {
-		r.ambientProg.LightColor.Set(l.Color)
+		ambientProg.LightColor.Set(l.Color)
 		r.pointLightMesh.Place(l.Position)
-		r.setMesh(r.ambientProg, r.pointLightMesh)
+		r.setMesh(ambientProg, r.pointLightMesh)
 		for _, subMesh := range r.pointLightMesh.SubMeshes {
-			r.setSubMesh(r.ambientProg, subMesh)
-			r.ambientProg.Render(subMesh.Geo.Inds, r.renderOpts)
+			r.setSubMesh(ambientProg, subMesh)
+			ambientProg.Render(subMesh.Geo.Inds, r.renderOpts)
 		}
 	}
 
 	for _, l := range s.SpotLights {
-		r.ambientProg.LightColor.Set(l.Color)
+		ambientProg.LightColor.Set(l.Color)
 		r.spotLightMesh.Place(l.Position)
 		r.spotLightMesh.Orient(l.UnitX, l.UnitY)
-		r.setMesh(r.ambientProg, r.spotLightMesh)
+		r.setMesh(ambientProg, r.spotLightMesh)
 		for _, subMesh := range r.spotLightMesh.SubMeshes {
-			r.setSubMesh(r.ambientProg, subMesh)
-			r.ambientProg.Render(subMesh.Geo.Inds, r.renderOpts)
+			r.setSubMesh(ambientProg, subMesh)
+			ambientProg.Render(subMesh.Geo.Inds, r.renderOpts)
+		}
+	}
+
+	for _, l := range s.AreaLights {
+		ambientProg.LightColor.Set(l.Color.Scale(l.Intensity)) // emissive
+		m := r.areaLightMesh(l)
+		r.setMesh(ambientProg, m)
+		for _, subMesh := range m.SubMeshes {
+			r.setSubMesh(ambientProg, subMesh)
+			ambientProg.Render(subMesh.Geo.Inds, r.renderOpts)
+		}
+	}
+}
+
+func (r *MeshRenderer) surfacePass(s *scene.Scene, c camera.Camera) {
+	r.renderOpts.Blending = graphics.NoBlending
+	r.renderOpts.DepthTest = graphics.EqualDepthTest
+
+	if r.surfaceTarget != nil {
+		defines := []string{"SURFACE"}
+		if r.MaterialNormalEnabled {
+			defines = append(defines, "NORMALMAP")
+		}
+		sp := r.meshProgram(defines...)
+		if sp != nil {
+			sp.Color.Set(r.surfaceTarget)
+			sp.Depth.Set(r.depthTarget)
+			r.setCamera(sp, c)
+			r.renderMeshes(s, c, sp, stateOrder, nil)
 		}
 	}
+
+	// reuse the surface target if it has the same normals
+	if r.normalTarget != nil && r.normalTarget != r.surfaceTarget {
+		sp := r.meshProgram("SURFACE")
+		if sp == nil {
+			return
+		}
+		sp.Color.Set(r.normalTarget)
+		sp.Depth.Set(r.depthTarget)
+		r.setCamera(sp, c)
+		r.renderMeshes(s, c, sp, stateOrder, nil)
+	}
 }
 
 func (r *MeshRenderer) ambientPass(s *scene.Scene, c camera.Camera) {
@@ -481,35 +839,51 @@ func (r *MeshRenderer) ambientPass(s *scene.Scene, c camera.Camera) {
 	r.renderOpts.Blending = graphics.NoBlending
 	r.renderOpts.DepthTest = graphics.EqualDepthTest
 
+	ambientProg := r.meshProgram("AMBIENT")
+	if ambientProg == nil {
+		return
+	}
+	r.setTargets(ambientProg)
+
 	if r.AmbientOcclusion {
-		r.ambientProg.AoMap.Set(r.blurredAoMap)
+		ambientProg.AoMap.Set(r.blurredAoMap)
 	} else {
-		r.ambientProg.AoMap.Set(r.resources.whiteTexture)
+		ambientProg.AoMap.Set(r.resources.whiteTexture)
 	}
-	r.ambientProg.LightColor.Set(s.AmbientLight.Color)
-	r.setCamera(r.ambientProg, c)
-	r.renderMeshes(s, c, r.ambientProg)
+	ambientProg.LightColor.Set(s.AmbientLight.Color)
+	r.setCamera(ambientProg, c)
+	r.renderMeshes(s, c, ambientProg, stateOrder, nil)
 
 	// render light source
 	// TODO: do with shaders instead for fancier effects?
 	for _, l := range s.PointLights {
-		r.ambientProg.LightColor.Set(l.Color)
+		ambientProg.LightColor.Set(l.Color)
 		r.pointLightMesh.Place(l.Position)
-		r.setMesh(r.ambientProg, r.pointLightMesh)
+		r.setMesh(ambientProg, r.pointLightMesh)
 		for _, subMesh := range r.pointLightMesh.SubMeshes {
-			r.setSubMesh(r.ambientProg, subMesh)
-			r.ambientProg.Render(subMesh.Geo.Inds, r.renderOpts)
+			r.setSubMesh(ambientProg, subMesh)
+			ambientProg.Render(subMesh.Geo.Inds, r.renderOpts)
 		}
 	}
 
 	for _, l := range s.SpotLights {
-		r.ambientProg.LightColor.Set(l.Color)
+		ambientProg.LightColor.Set(l.Color)
 		r.spotLightMesh.Place(l.Position)
 		r.spotLightMesh.Orient(l.UnitX, l.UnitY)
-		r.setMesh(r.ambientProg, r.spotLightMesh)
+		r.setMesh(ambientProg, r.spotLightMesh)
 		for _, subMesh := range r.spotLightMesh.SubMeshes {
-			r.setSubMesh(r.ambientProg, subMesh)
-			r.ambientProg.Render(subMesh.Geo.Inds, r.renderOpts)
+			r.setSubMesh(ambientProg, subMesh)
+			ambientProg.Render(subMesh.Geo.Inds, r.renderOpts)
+		}
+	}
+
+	for _, l := range s.AreaLights {
+		ambientProg.LightColor.Set(l.Color.Scale(l.Intensity)) // emissive
+		m := r.areaLightMesh(l)
+		r.setMesh(ambientProg, m)
+		for _, subMesh := range m.SubMeshes {
+			r.setSubMesh(ambientProg, subMesh)
+			ambientProg.Render(subMesh.Geo.Inds, r.renderOpts)
 		}
 	}
 }
@@ -518,38 +892,171 @@ func (r *MeshRenderer) lightPass(s *scene.Scene, c camera.Camera) {
 	r.renderOpts.DepthTest = graphics.EqualDepthTest
 	r.renderOpts.Blending = graphics.AdditiveBlending // add to framebuffer contents
 
-	r.setCamera(r.pointLitProg, c)
+	// shade lights without shadows (or cookies) together in one clustered
+	// pass, and the rest in one pass each
+	if r.ClusteredShading {
+		r.lightClusters.reset(c)
+	}
+
 	for _, l := range s.PointLights {
-		r.setPointLight(r.pointLitProg, l)
-		r.renderMeshes(s, c, r.pointLitProg)
+		shadows := r.ShadowsEnabled && l.CastShadows
+		if r.ClusteredShading && !shadows {
+			r.lightClusters.addPointLight(l)
+			continue
+		}
+		scissor, visible := r.lightScissor(l.BoundingSphere(), c)
+		if !visible {
+			graphics.Stats.CountCulledLight()
+			continue
+		}
+		r.renderOpts.Scissor = &scissor
+		sp := r.lightProgram("POINT", shadows)
+		if sp == nil {
+			continue
+		}
+		r.setCamera(sp, c)
+		r.setPointLight(sp, l)
+		r.renderMeshes(s, c, sp, stateOrder, func(sm *object.SubMesh) bool {
+			return pointLightInteracts(l, sm)
+		})
 	}
 
-	r.setCamera(r.spotLitProg, c)
 	for _, l := range s.SpotLights {
-		r.setSpotLight(r.spotLitProg, l)
-		r.renderMeshes(s, c, r.spotLitProg)
+		shadows := r.ShadowsEnabled && l.CastShadows
+		if r.ClusteredShading && !shadows && l.Cookie == nil {
+			r.lightClusters.addSpotLight(l)
+			continue
+		}
+		scissor, visible := r.lightScissor(l.BoundingSphere(), c)
+		if !visible {
+			graphics.Stats.CountCulledLight()
+			continue
+		}
+		r.renderOpts.Scissor = &scissor
+		sp := r.lightProgram("SPOT", shadows)
+		if sp == nil {
+			continue
+		}
+		r.setCamera(sp, c)
+		r.setSpotLight(sp, l)
+		r.renderMeshes(s, c, sp, stateOrder, func(sm *object.SubMesh) bool {
+			return spotLightInteracts(l, sm)
+		})
 	}
 
-	r.setCamera(r.dirLitProg, c)
+	// TODO: cast shadows from area lights?
+	for _, l := range s.AreaLights {
+		scissor, visible := r.lightScissor(l.BoundingSphere(), c)
+		if !visible {
+			graphics.Stats.CountCulledLight()
+			continue
+		}
+		r.renderOpts.Scissor = &scissor
+		sp := r.lightProgram("AREA", false)
+		if sp == nil {
+			continue
+		}
+		r.setCamera(sp, c)
+		r.setAreaLight(sp, l, c)
+		r.renderMeshes(s, c, sp, stateOrder, func(sm *object.SubMesh) bool {
+			return areaLightInteracts(l, sm)
+		})
+	}
+
+	r.renderOpts.Scissor = nil
+
 	for _, l := range s.DirectionalLights {
-		r.setDirectionalLight(r.dirLitProg, l)
-		r.renderMeshes(s, c, r.dirLitProg)
+		sp := r.lightProgram("DIR", r.ShadowsEnabled && l.CastShadows)
+		if sp == nil {
+			continue
+		}
+		r.setCamera(sp, c)
+		r.setDirectionalLight(sp, l)
+		r.renderMeshes(s, c, sp, stateOrder, nil)
+	}
+
+	if r.ClusteredShading && !r.lightClusters.empty() {
+		r.clusteredPass(s, c)
+	}
+}
+
+// areaLightMesh returns a mesh covering the area of l
+func (r *MeshRenderer) areaLightMesh(l *light.AreaLight) *object.Mesh {
+	m := r.rectangleLightMesh
+	if l.Shape == light.DiskShape {
+		m = r.diskLightMesh
+	}
+	m.Place(l.Position)
+	m.Orient(l.UnitX, l.UnitY)
+	m.SetScale(math.Vec3{l.Width, l.Height, 1})
+	return m
+}
+
+func (r *MeshRenderer) clusteredPass(s *scene.Scene, c camera.Camera) {
+	r.lightClusters.upload()
+
+	sp := r.lightProgram("CLUSTERED", false)
+	if sp == nil {
+		return
 	}
+	r.setCamera(sp, c)
+	sp.LightBuffer.Set(r.lightClusters.lightBuffer)
+	sp.ClusterBuffer.Set(r.lightClusters.clusterBuffer)
+	sp.LightIndexBuffer.Set(r.lightClusters.indexBuffer)
+	sp.ClusterNear.Set(r.lightClusters.near)
+	sp.ClusterFar.Set(r.lightClusters.far)
+	sp.ViewportWidth.Set(r.colorTarget.Width())
+	sp.ViewportHeight.Set(r.colorTarget.Height())
+	r.renderMeshes(s, c, sp, stateOrder, nil)
 }
 
-func (r *MeshRenderer) renderMeshes(s *scene.Scene, c camera.Camera, sp *MeshProgram) {
+// renderMeshes draws all unculled submeshes that interact with the current
+// light, or all of them if interacts is nil
+func (r *MeshRenderer) renderMeshes(s *scene.Scene, c camera.Camera, sp *MeshProgram, order queueOrder, interacts func(sm *object.SubMesh) bool) {
+	r.queue.reset()
+	culled := 0
+	lightCulled := 0
 	j := 0
 	for i, m := range s.Meshes {
-		r.setMesh(sp, m)
-		sp.NormalMatrix.Set(&r.normalMatrices[i])
 		for _, sm := range m.SubMeshes {
-			if !r.cullCache[j] {
-				r.setSubMesh(sp, sm)
-				sp.Render(sm.Geo.Inds, r.renderOpts)
+			if r.cullCache[j] {
+				culled++
+			} else if interacts != nil && !interacts(sm) {
+				lightCulled++
+			} else {
+				mtlID := r.resources.id(sm.Mtl)
+				bufID := r.resources.id(&sm.Geo.Verts[0])
+				key := drawKey(order, i, mtlID, bufID, r.depthCache[j])
+				r.queue.add(key, i, sm)
 			}
 			j++
 		}
 	}
+	r.queue.sort()
+	graphics.Stats.CountSubMeshes(len(r.queue.items), culled)
+	graphics.Stats.CountLightCulledSubMeshes(lightCulled)
+
+	// only change state that differs from the previous draw
+	lastMesh := -1
+	var lastMtl *material.Material
+	var lastGeo *object.Geometry
+	for _, item := range r.queue.items {
+		sm := item.subMesh
+		if item.meshIndex != lastMesh {
+			r.setMesh(sp, s.Meshes[item.meshIndex])
+			sp.NormalMatrix.Set(&r.normalMatrices[item.meshIndex])
+			lastMesh = item.meshIndex
+		}
+		if sm.Mtl != lastMtl {
+			r.setMaterial(sp, sm.Mtl)
+			lastMtl = sm.Mtl
+		}
+		if sm.Geo != lastGeo {
+			r.setGeometry(sp, sm)
+			lastGeo = sm.Geo
+		}
+		sp.Render(sm.Geo.Inds, r.renderOpts)
+	}
 }
 
 func (r *MeshRenderer) setCamera(sp *MeshProgram, c camera.Camera) {
@@ -559,10 +1066,17 @@ func (r *MeshRenderer) setCamera(sp *MeshProgram, c camera.Camera) {
 
 func (r *MeshRenderer) setMesh(sp *MeshProgram, m *object.Mesh) {
 	sp.ModelMatrix.Set(m.WorldMatrix())
+	if sp.PreviousModelMatrix != nil {
+		sp.PreviousModelMatrix.Set(r.previousWorldMatrix(m))
+	}
 }
 
 func (r *MeshRenderer) setSubMesh(sp *MeshProgram, sm *object.SubMesh) {
-	mtl := sm.Mtl
+	r.setMaterial(sp, sm.Mtl)
+	r.setGeometry(sp, sm)
+}
+
+func (r *MeshRenderer) setMaterial(sp *MeshProgram, mtl *material.Material) {
 
 	if r.MaterialAmbientEnabled {
 		tex := r.resources.texture(mtl.AmbientMap)
@@ -612,7 +1126,9 @@ func (r *MeshRenderer) setSubMesh(sp *MeshProgram, sm *object.SubMesh) {
 		sp.MaterialBumpMapWidth.Set(r.resources.whiteTexture.Width())
 		sp.MaterialBumpMapHeight.Set(r.resources.whiteTexture.Height())
 	}
+}
 
+func (r *MeshRenderer) setGeometry(sp *MeshProgram, sm *object.SubMesh) {
 	vbo := r.resources.vertexBuffer(sm)
 	ibo := r.resources.indexBuffer(sm)
 
@@ -631,30 +1147,62 @@ func (r *MeshRenderer) setPointLight(sp *MeshProgram, l *light.PointLight) {
 	sp.LightPosition.Set(l.Position)
 	sp.LightColor.Set(l.Color.Scale(l.Intensity))
 	if r.ShadowsEnabled && l.CastShadows {
-		sp.ShadowFar.Set(l.ShadowFar)
+		sp.ShadowFar.Set(l.ShadowDistance())
 		smap := r.resources.pointShadowMap(l)
 		sp.ShadowMap.Set(smap)
 	} else {
 		sp.ShadowFar.Set(float32(100))
 		sp.ShadowMap.Set(r.resources.whiteCubeMap)
 	}
-	sp.LightAttenuation.Set(l.Attenuation)
+	sp.LightAttenuation.Set(attenuationTerms(&l.Attenuation))
+	sp.LightRange.Set(lightRange(&l.Attenuation))
+}
+
+// attenuationTerms packs the constant, linear and quadratic terms for the shaders
+func attenuationTerms(a *light.Attenuation) math.Vec3 {
+	return math.Vec3{a.AttenuationConstant, a.AttenuationLinear, a.AttenuationQuadratic}
+}
+
+// lightRange returns the distance a light is culled at, and where the shaders
+// fade it out so culling leaves no hard edges, or 0 if it reaches infinitely far
+func lightRange(a *light.Attenuation) float32 {
+	rng := a.InfluenceRange()
+	if gomath.IsInf(float64(rng), +1) {
+		return 0
+	}
+	return rng
 }
 
 func (r *MeshRenderer) setSpotLight(sp *MeshProgram, l *light.SpotLight) {
 	sp.LightPosition.Set(l.Position)
 	sp.LightDirection.Set(l.Forward())
 	sp.LightColor.Set(l.Color.Scale(l.Intensity))
-	sp.LightAttenuation.Set(l.Attenuation)
-	sp.LightCosAngle.Set(float32(gomath.Cos(float64(l.FOV/2))))
+	sp.LightAttenuation.Set(attenuationTerms(&l.Attenuation))
+	sp.LightRange.Set(lightRange(&l.Attenuation))
+	cosInner, cosOuter := l.ConeCosines()
+	sp.LightCosAngle.Set(cosOuter)
+	sp.LightCosInnerAngle.Set(cosInner)
+
+	if l.Cookie != nil {
+		// project over the outer cone from the light's point of view
+		// (only x and y are used, so the clip planes do not matter)
+		var m math.Mat4
+		m.Perspective(l.FOV, 1, 0.1, 1)
+		m.Mult(l.ViewMatrix())
+		sp.CookieProjectionViewMatrix.Set(&m)
+		sp.LightCookie.Set(r.resources.texture(l.Cookie))
+	} else {
+		sp.LightCookie.Set(r.resources.whiteTexture)
+	}
 
 	if r.ShadowsEnabled && l.CastShadows {
+		c := spotShadowCamera(l)
 		var m math.Mat4
 		m.Identity()
-		m.Mult(l.ProjectionMatrix())
-		m.Mult(l.ViewMatrix())
+		m.Mult(c.ProjectionMatrix())
+		m.Mult(c.ViewMatrix())
 		sp.ShadowProjectionViewMatrix.Set(&m)
-		sp.ShadowFar.Set(l.PerspectiveCamera.Far)
+		sp.ShadowFar.Set(l.ShadowDistance())
 		smap := r.resources.spotShadowMap(l)
 		sp.ShadowMap.Set(smap)
 	} else {
@@ -663,10 +1211,30 @@ func (r *MeshRenderer) setSpotLight(sp *MeshProgram, l *light.SpotLight) {
 	}
 }
 
+func (r *MeshRenderer) setAreaLight(sp *MeshProgram, l *light.AreaLight, c camera.Camera) {
+	points := l.Outline(maxAreaLightPoints)
+	for i, point := range points {
+		sp.LightPoints[i].Set(point.Vec4(1).Transform(c.ViewMatrix()).Vec3())
+	}
+	sp.LightPointCount.Set(len(points))
+	sp.LightNormal.Set(l.Normal().Vec4(0).Transform(c.ViewMatrix()).Vec3())
+	sp.LightPosition.Set(l.Position.Vec4(1).Transform(c.ViewMatrix()).Vec3())
+	sp.LightColor.Set(l.Color.Scale(l.Intensity))
+	sp.LightRange.Set(l.Range)
+	if l.TwoSided {
+		sp.LightTwoSided.Set(int32(1))
+	} else {
+		sp.LightTwoSided.Set(int32(0))
+	}
+	sp.LtcMatrixMap.Set(r.ltcMatrixMap)
+	sp.LtcAmplitudeMap.Set(r.ltcAmplitudeMap)
+}
+
 func (r *MeshRenderer) setDirectionalLight(sp *MeshProgram, l *light.DirectionalLight) {
 	sp.LightDirection.Set(l.Forward())
 	sp.LightColor.Set(l.Color.Scale(l.Intensity))
-	sp.LightAttenuation.Set(float32(0))
+	sp.LightAttenuation.Set(math.Vec3{1, 0, 0})
+	sp.LightRange.Set(float32(0))
 
 	if r.ShadowsEnabled && l.CastShadows {
 		var m math.Mat4
@@ -717,18 +1285,21 @@ func (r *MeshRenderer) shadowPass(s *scene.Scene) {
 		if l.CastShadows {
 			smap := r.resources.pointShadowMap(l)
 			r.shadowMapRenderer.renderPointLightShadowMap(s, l, smap)
+			graphics.Stats.CountShadowMap()
 		}
 	}
 	for _, l := range s.SpotLights {
 		if l.CastShadows {
 			smap := r.resources.spotShadowMap(l)
 			r.shadowMapRenderer.renderSpotLightShadowMap(s, l, smap)
+			graphics.Stats.CountShadowMap()
 		}
 	}
 	for _, l := range s.DirectionalLights {
 		if l.CastShadows {
 			smap := r.resources.dirShadowMap(l)
 			r.shadowMapRenderer.renderDirectionalLightShadowMap(s, l, smap)
+			graphics.Stats.CountShadowMap()
 		}
 	}
 }
@@ -759,7 +1330,7 @@ func (r *ShadowMapRenderer) renderPointLightShadowMap(s *scene.Scene, l *light.P
 		math.Vec3{0, -1, 0},
 	}
 
-	c := camera.NewPerspectiveCamera(90, 1, 0.1, l.ShadowFar)
+	c := camera.NewPerspectiveCamera(90, 1, 0.1, l.ShadowDistance())
 	c.Place(l.Position)
 
 	for face := 0; face < 6; face++ {
@@ -781,30 +1352,48 @@ func (r *ShadowMapRenderer) renderPointLightShadowMap(s *scene.Scene, l *light.P
 			r.setSubMesh(r.shadowSp1, subMesh)
 
 			r.shadowSp1.Render(subMesh.Geo.Inds, r.shadowRenderOpts)
+			graphics.Stats.CountSubMeshes(1, 0)
 		}
 	}
 
 	//l.DirtyShadowMap = false
 }
 
+// spotShadowCamera returns the camera that l's shadow map is rendered from,
+// which reaches out to the shadow distance
+func spotShadowCamera(l *light.SpotLight) *camera.PerspectiveCamera {
+	c := camera.NewPerspectiveCamera(90, 1, 0.1, l.ShadowDistance())
+	c.Place(l.Position)
+	c.Orient(l.UnitX, l.UnitY)
+	return c
+}
+
 func (r *ShadowMapRenderer) renderSpotLightShadowMap(s *scene.Scene, l *light.SpotLight, smap *graphics.Texture2D) {
 	// TODO: re-render also when objects have moved
 	//if !l.DirtyShadowMap {
 		//return
 	//}
 
+	c := spotShadowCamera(l)
+
 	r.shadowSp2.Depth.Set(smap)
 	smap.Clear(math.Vec4{1, 1, 1, 1})
-	r.setCamera(r.shadowSp2, &l.PerspectiveCamera)
+	r.setCamera(r.shadowSp2, c)
 
 	for _, m := range s.Meshes {
 		r.setMesh(r.shadowSp2, m)
 		for _, subMesh := range m.SubMeshes {
-			if !l.PerspectiveCamera.Cull(subMesh) {
+			culled := c.Cull(subMesh)
+			if !culled {
 				r.setSubMesh(r.shadowSp2, subMesh)
 
 				r.shadowSp2.Render(subMesh.Geo.Inds, r.shadowRenderOpts)
 			}
+			if culled {
+				graphics.Stats.CountSubMeshes(0, 1)
+			} else {
+				graphics.Stats.CountSubMeshes(1, 0)
+			}
 		}
 	}
 
@@ -824,11 +1413,17 @@ func (r *ShadowMapRenderer) renderDirectionalLightShadowMap(s *scene.Scene, l *l
 	for _, m := range s.Meshes {
 		r.setMesh(r.shadowSp3, m)
 		for _, subMesh := range m.SubMeshes {
-			if !l.OrthoCamera.Cull(subMesh) {
+			culled := l.OrthoCamera.Cull(subMesh)
+			if !culled {
 				r.setSubMesh(r.shadowSp3, subMesh)
 
 				r.shadowSp3.Render(subMesh.Geo.Inds, r.shadowRenderOpts)
 			}
+			if culled {
+				graphics.Stats.CountSubMeshes(0, 1)
+			} else {
+				graphics.Stats.CountSubMeshes(1, 0)
+			}
 		}
 	}
 
@@ -836,18 +1431,103 @@ func (r *ShadowMapRenderer) renderDirectionalLightShadowMap(s *scene.Scene, l *l
 }
 
 func pointLightInteracts(l *light.PointLight, sm *object.SubMesh) bool {
+	return l.BoundingSphere().IntersectsSphere(sm.BoundingSphere())
+}
+
+func spotLightInteracts(l *light.SpotLight, sm *object.SubMesh) bool {
+	if gomath.IsInf(float64(l.InfluenceRange()), +1) {
+		return l.BoundingCone().ExtendedIntersectsSphere(sm.BoundingSphere())
+	}
+	return l.BoundingCone().IntersectsSphere(sm.BoundingSphere())
+}
+
+// areaLightInteracts tests if sm is in range of l, and in front of it
+// unless it is two-sided
+func areaLightInteracts(l *light.AreaLight, sm *object.SubMesh) bool {
 	sphere := sm.BoundingSphere()
-	dist := l.Position.Sub(sphere.Center).Length()
-	if dist < sphere.Radius {
-		return true
+	if !l.TwoSided && sphere.Center.Sub(l.Position).Dot(l.Normal()) < -sphere.Radius {
+		return false
 	}
-	dist = dist - sphere.Radius
-	return dist*dist < (1/0.05-1)/l.Attenuation
+	return l.BoundingSphere().IntersectsSphere(sphere)
+}
+
+// clipPlanes recovers the near and far distances from a perspective or
+// orthographic projection
+func clipPlanes(proj *math.Mat4) (near, far float32) {
+	a := proj.At(2, 2)
+	b := proj.At(2, 3)
+	if proj.At(3, 3) != 0 {
+		// orthographic, with depth mapped linearly to clip space
+		return (b + 1) / a, (b - 1) / a
+	}
+	return b / (a - 1), b / (a + 1)
+}
+
+// projectSphere bounds the projection of a view space sphere in normalized
+// device coordinates. It is the whole screen if the sphere crosses the near
+// plane, and not visible if the sphere is entirely closer than the near plane.
+func projectSphere(center math.Vec3, radius float32, proj *math.Mat4, near float32) (min, max math.Vec2, visible bool) {
+	if -center.Z()+radius < near {
+		return min, max, false
+	}
+	if -center.Z()-radius <= near {
+		return math.Vec2{-1, -1}, math.Vec2{+1, +1}, true
+	}
+
+	// bound the projections of the corners of the box around the sphere
+	inf := float32(gomath.Inf(+1))
+	min = math.Vec2{+inf, +inf}
+	max = math.Vec2{-inf, -inf}
+	for i := 0; i < 8; i++ {
+		corner := center
+		for j := 0; j < 3; j++ {
+			if i&(1<<uint(j)) == 0 {
+				corner[j] -= radius
+			} else {
+				corner[j] += radius
+			}
+		}
+		clip := corner.Vec4(1).Transform(proj)
+		ndc := math.Vec2{clip.X() / clip.W(), clip.Y() / clip.W()}
+		min = math.Vec2{math.Min(min.X(), ndc.X()), math.Min(min.Y(), ndc.Y())}
+		max = math.Vec2{math.Max(max.X(), ndc.X()), math.Max(max.Y(), ndc.Y())}
+	}
+
+	visible = max.X() >= -1 && min.X() <= +1 && max.Y() >= -1 && min.Y() <= +1
+	return min, max, visible
+}
+
+// lightScissor returns the pixels of the render targets that a world space
+// bounding sphere of a light can cover
+func (r *MeshRenderer) lightScissor(sphere *object.Sphere, c camera.Camera) (graphics.Rect, bool) {
+	var rect graphics.Rect
+	if gomath.IsInf(float64(sphere.Radius), +1) {
+		rect.Width, rect.Height = r.colorTarget.Width(), r.colorTarget.Height()
+		return rect, true
+	}
+
+	near, _ := clipPlanes(c.ProjectionMatrix())
+	center := sphere.Center.Vec4(1).Transform(c.ViewMatrix()).Vec3()
+	min, max, visible := projectSphere(center, sphere.Radius, c.ProjectionMatrix(), near)
+	if !visible {
+		return rect, false
+	}
+
+	w := float32(r.colorTarget.Width())
+	h := float32(r.colorTarget.Height())
+	x1 := clampInt(int(gomath.Floor(float64((min.X()+1)/2*w))), 0, int(w))
+	y1 := clampInt(int(gomath.Floor(float64((min.Y()+1)/2*h))), 0, int(h))
+	x2 := clampInt(int(gomath.Ceil(float64((max.X()+1)/2*w))), 0, int(w))
+	y2 := clampInt(int(gomath.Ceil(float64((max.Y()+1)/2*h))), 0, int(h))
+	rect.X, rect.Y = x1, y1
+	rect.Width, rect.Height = x2-x1, y2-y1
+	return rect, true
 }
 
 func newMeshResourceManager() *meshResourceManager {
 	var rman meshResourceManager
 
+	rman.ids = make(map[interface{}]int)
 	rman.vbos = make(map[*object.Vertex]*graphics.VertexBuffer)
 	rman.ibos = make(map[*int32]*graphics.IndexBuffer)
 	rman.pointLightShadowMaps = make(map[int]*graphics.CubeMap)
@@ -863,6 +1543,25 @@ func newMeshResourceManager() *meshResourceManager {
 	return &rman
 }
 
+// id returns a small number identifying key (e.g. a program or material),
+// which is used to sort draws by state. Ids are only valid until resetIDs.
+func (rman *meshResourceManager) id(key interface{}) int {
+	id, found := rman.ids[key]
+	if !found {
+		id = len(rman.ids)
+		rman.ids[key] = id
+	}
+	return id
+}
+
+// resetIDs forgets all ids, so they stay small and do not pile up
+// for objects that are no longer in the scene
+func (rman *meshResourceManager) resetIDs() {
+	for key := range rman.ids {
+		delete(rman.ids, key)
+	}
+}
+
 func (rman *meshResourceManager) vertexBuffer(sm *object.SubMesh) *graphics.VertexBuffer {
 	vbo, found := rman.vbos[&sm.Geo.Verts[0]]
 	if !found {
@@ -0,0 +1,48 @@
+package render
+
+import (
+	"github.com/hersle/gl3d/math"
+	gomath "math"
+	"testing"
+)
+
+func TestProjectSphere(t *testing.T) {
+	var proj math.Mat4
+	proj.Perspective(gomath.Pi/2, 1, 1, 100)
+
+	tests := []struct {
+		center   math.Vec3
+		radius   float32
+		min, max math.Vec2
+		visible  bool
+	}{
+		// crosses the near plane
+		{math.Vec3{0, 0, -1}, 0.5, math.Vec2{-1, -1}, math.Vec2{+1, +1}, true},
+		{math.Vec3{50, 0, 0}, 2, math.Vec2{-1, -1}, math.Vec2{+1, +1}, true},
+
+		// entirely closer than the near plane
+		{math.Vec3{0, 0, -0.5}, 0.25, math.Vec2{}, math.Vec2{}, false},
+		{math.Vec3{0, 0, 10}, 1, math.Vec2{}, math.Vec2{}, false},
+
+		// the box around the sphere projects to [-1/9, 1/9]
+		{math.Vec3{0, 0, -10}, 1, math.Vec2{-1.0 / 9, -1.0 / 9}, math.Vec2{1.0 / 9, 1.0 / 9}, true},
+
+		// off to the side
+		{math.Vec3{20, 0, -10}, 1, math.Vec2{19.0 / 11, -1.0 / 9}, math.Vec2{21.0 / 9, 1.0 / 9}, false},
+	}
+	for _, test := range tests {
+		min, max, visible := projectSphere(test.center, test.radius, &proj, 1)
+		if visible != test.visible {
+			t.Errorf("sphere at %v: got visible %t, expected %t", test.center, visible, test.visible)
+		}
+		if !visible {
+			continue
+		}
+		for i := 0; i < 2; i++ {
+			if !approxEqual(min[i], test.min[i], 1e-4) || !approxEqual(max[i], test.max[i], 1e-4) {
+				t.Errorf("sphere at %v: got %v to %v, expected %v to %v", test.center, min, max, test.min, test.max)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package render
+
+import (
+	"github.com/hersle/gl3d/camera"
+	"github.com/hersle/gl3d/graphics"
+	"github.com/hersle/gl3d/math"
+	"github.com/hersle/gl3d/object"
+	"github.com/hersle/gl3d/scene"
+)
+
+type occlusionProgram struct {
+	*graphics.Program
+
+	BoxPosition      *graphics.Uniform
+	BoxSize          *graphics.Uniform
+	ViewMatrix       *graphics.Uniform
+	ProjectionMatrix *graphics.Uniform
+
+	Depth *graphics.Output
+}
+
+// occlusionState tracks the visibility of a submesh across frames
+type occlusionState struct {
+	query   *graphics.OcclusionQuery
+	pending bool // query issued, but result not read
+	visible bool // result of the last query that was read
+	frame   int  // last frame the submesh was in the scene
+}
+
+// occlusionCuller culls submeshes whose bounding boxes were hidden behind the
+// contents of the depth buffer
+type occlusionCuller struct {
+	prog       *occlusionProgram
+	renderOpts *graphics.RenderOptions
+
+	states map[*object.SubMesh]*occlusionState
+	frame  int
+
+	queries []*occlusionState // to issue this frame
+	boxes   []*object.Box
+}
+
+func newOcclusionProgram() *occlusionProgram {
+	var sp occlusionProgram
+
+	vFile := "occlusionvshader.glsl"
+	fFile := "occlusionfshader.glsl"
+	sp.Program = readProgram(vFile, fFile, "")
+
+	sp.BoxPosition = sp.UniformByName("boxPosition")
+	sp.BoxSize = sp.UniformByName("boxSize")
+	sp.ViewMatrix = sp.UniformByName("viewMatrix")
+	sp.ProjectionMatrix = sp.UniformByName("projectionMatrix")
+	sp.Depth = sp.OutputDepth()
+
+	return &sp
+}
+
+func newOcclusionCuller() *occlusionCuller {
+	var oc occlusionCuller
+
+	oc.prog = newOcclusionProgram()
+
+	// test against the depth buffer without modifying it
+	oc.renderOpts = graphics.NewRenderOptions()
+	oc.renderOpts.DepthTest = graphics.LessEqualDepthTest
+	oc.renderOpts.Culling = graphics.NoCulling
+	oc.renderOpts.Primitive = graphics.TriangleStrip
+	oc.renderOpts.WriteMask = graphics.NoWrite
+
+	oc.states = make(map[*object.SubMesh]*occlusionState)
+
+	return &oc
+}
+
+func (oc *occlusionCuller) state(sm *object.SubMesh) *occlusionState {
+	st, found := oc.states[sm]
+	if !found {
+		st = &occlusionState{}
+		st.query = graphics.NewOcclusionQuery()
+		st.visible = true // until proven otherwise
+		oc.states[sm] = st
+	}
+	return st
+}
+
+// cull marks submeshes that were hidden in the depth buffer in cullCache,
+// before any pass draws them. Results are only read once the GPU has them,
+// so visibility can lag a frame or more behind instead of stalling the
+// pipeline, but submeshes stay visible until their latest query has
+// confirmed that they are hidden.
+func (oc *occlusionCuller) cull(s *scene.Scene, c camera.Camera, cullCache []bool) {
+	var projView math.Mat4
+	projView.Identity()
+	projView.Mult(c.ProjectionMatrix())
+	projView.Mult(c.ViewMatrix())
+
+	oc.frame++
+	oc.queries = oc.queries[:0]
+	oc.boxes = oc.boxes[:0]
+
+	i := 0
+	for _, m := range s.Meshes {
+		for _, sm := range m.SubMeshes {
+			if cullCache[i] {
+				// keep the state of submeshes outside the frustum
+				if st, found := oc.states[sm]; found {
+					st.frame = oc.frame
+				}
+			} else {
+				st := oc.state(sm)
+				st.frame = oc.frame
+				if st.pending && st.query.Available() {
+					st.visible = st.query.Visible()
+					st.pending = false
+				}
+
+				bbox := sm.BoundingBox()
+				if crossesNearPlane(bbox, &projView) {
+					st.visible = true // the clipped box says nothing
+				} else if !st.pending {
+					oc.queries = append(oc.queries, st)
+					oc.boxes = append(oc.boxes, bbox)
+				}
+
+				cullCache[i] = !st.pending && !st.visible
+			}
+			i++
+		}
+	}
+
+	// forget submeshes that were removed from the scene
+	for sm, st := range oc.states {
+		if st.frame != oc.frame {
+			st.query.Delete()
+			delete(oc.states, sm)
+		}
+	}
+}
+
+// test queries the visibility of the submeshes marked by cull against the
+// depth buffer, for culling them in a later frame
+func (oc *occlusionCuller) test(c camera.Camera, depthTarget graphics.RenderTarget) {
+	oc.prog.Depth.Set(depthTarget)
+	oc.prog.ViewMatrix.Set(c.ViewMatrix())
+	oc.prog.ProjectionMatrix.Set(c.ProjectionMatrix())
+
+	for i, st := range oc.queries {
+		oc.query(st, oc.boxes[i])
+	}
+}
+
+// reset forgets all results, which are stale after culling has been off
+func (oc *occlusionCuller) reset() {
+	for sm, st := range oc.states {
+		st.query.Delete()
+		delete(oc.states, sm)
+	}
+}
+
+func (oc *occlusionCuller) query(st *occlusionState, bbox *object.Box) {
+	// pad the box so it is not hidden by the surfaces it bounds
+	pad := 0.01 * bbox.DiagonalLength()
+	oc.prog.BoxPosition.Set(bbox.Position.Sub(math.Vec3{pad, pad, pad}))
+	oc.prog.BoxSize.Set(math.Vec3{bbox.Dx + 2*pad, bbox.Dy + 2*pad, bbox.Dz + 2*pad})
+
+	st.query.Begin()
+	oc.prog.Render(14, oc.renderOpts)
+	st.query.End()
+	st.pending = true
+}
+
+func crossesNearPlane(bbox *object.Box, projView *math.Mat4) bool {
+	for _, p := range bbox.Points() {
+		clip := p.Vec4(1).Transform(projView)
+		if clip.Z() < -clip.W() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package render
+
+import (
+	"github.com/hersle/gl3d/camera"
+	"github.com/hersle/gl3d/graphics"
+	"github.com/hersle/gl3d/math"
+	"github.com/hersle/gl3d/scene"
+)
+
+// PostEffect is a full screen effect applied to the rendered scene
+type PostEffect interface {
+	// Render draws the effect with the scene colors in frame.Source.
+	// It returns true if it wrote the result to frame.Target,
+	// or false if it modified frame.Source in place (or did nothing).
+	Render(frame *PostFrame) bool
+}
+
+// PostEffectFunc lets an ordinary function be used as a PostEffect
+type PostEffectFunc func(frame *PostFrame) bool
+
+func (f PostEffectFunc) Render(frame *PostFrame) bool {
+	return f(frame)
+}
+
+// PostFrame is what a PostEffect gets to work with
+type PostFrame struct {
+	Source *graphics.Texture2D // floating point scene colors
+	Target *graphics.Texture2D // same size and format as Source
+	Depth  *graphics.Texture2D
+
+	Scene               *scene.Scene
+	Camera              camera.Camera
+	ViewMatrix          *math.Mat4
+	ProjectionMatrix    *math.Mat4
+	InvViewMatrix       math.Mat4
+	InvProjectionMatrix math.Mat4
+
+	// without jitter, for tracking motion
+	ProjectionViewMatrix         *math.Mat4
+	PreviousProjectionViewMatrix *math.Mat4
+
+	Velocity *graphics.Texture2D // per pixel motion of meshes in texture coordinates, or nil
+	Surface  *graphics.Texture2D // view space normals and reflectivity, or nil
+	Normals  *graphics.Texture2D // view space normals without normal mapping, or nil
+
+	Skybox *graphics.CubeMap // nil if the scene has none
+
+	Quad *graphics.VertexBuffer // 6 vec2 vertices in two triangles covering the screen
+}
+
+func (r *Renderer) AddPostEffect(e PostEffect) {
+	r.PostEffects = append(r.PostEffects, e)
+}
+
+// renderPostEffects applies the effect chain in order, swapping the scene
+// targets whenever an effect writes to the other one
+func (r *Renderer) renderPostEffects(s *scene.Scene, c camera.Camera) {
+	var frame PostFrame
+	frame.Depth = r.sceneDepthRenderTarget
+	frame.Scene = s
+	frame.Camera = c
+	frame.ViewMatrix = c.ViewMatrix()
+	frame.ProjectionMatrix = c.ProjectionMatrix()
+	frame.InvViewMatrix.Identity()
+	frame.InvViewMatrix.Mult(frame.ViewMatrix)
+	frame.InvViewMatrix.Invert()
+	frame.InvProjectionMatrix.Identity()
+	frame.InvProjectionMatrix.Mult(frame.ProjectionMatrix)
+	frame.InvProjectionMatrix.Invert()
+	frame.ProjectionViewMatrix = &r.projViewMat
+	frame.PreviousProjectionViewMatrix = &r.previousProjViewMat
+	frame.Velocity = r.MeshRenderer.velocityTarget
+	frame.Surface = r.MeshRenderer.surfaceTarget
+	frame.Normals = r.MeshRenderer.normalTarget
+	if s.Skybox != nil {
+		frame.Skybox = r.SkyboxRenderer.cubeMap(s.Skybox)
+	}
+	frame.Quad = r.EffectRenderer.vbo
+
+	for _, e := range r.PostEffects {
+		frame.Source = r.sceneRenderTarget
+		frame.Target = r.sceneRenderTarget2
+		if e.Render(&frame) {
+			r.sceneRenderTarget, r.sceneRenderTarget2 = r.sceneRenderTarget2, r.sceneRenderTarget
+		}
+	}
+}
+
+// the built in effects are controlled by the settings on the Renderer
+
+type reflectionsEffect struct {
+	r *Renderer
+}
+
+func (e reflectionsEffect) Render(frame *PostFrame) bool {
+	if !e.r.Reflections.Enabled || frame.Surface == nil {
+		return false
+	}
+	e.r.EffectRenderer.RenderReflections(frame.Camera, frame.Source, frame.Depth, frame.Surface, frame.Target, frame.Skybox, &e.r.Reflections)
+	return true
+}
+
+type fogEffect struct {
+	r *Renderer
+}
+
+func (e fogEffect) Render(frame *PostFrame) bool {
+	if e.r.Fog.enabled() {
+		e.r.EffectRenderer.RenderFog(frame.Camera, frame.Depth, frame.Source, &e.r.Fog, frame.Skybox)
+	}
+	return false
+}
+
+type volumetricLightEffect struct {
+	r *Renderer
+}
+
+func (e volumetricLightEffect) Render(frame *PostFrame) bool {
+	if !e.r.VolumetricLight.Enabled {
+		return false
+	}
+
+	graphics.BeginPass("volumetric")
+	defer graphics.EndPass()
+
+	mr := e.r.MeshRenderer
+	er := e.r.EffectRenderer
+	er.beginScattering(frame.Camera, frame.Depth, &e.r.VolumetricLight)
+	for _, l := range frame.Scene.SpotLights {
+		var smap *graphics.Texture2D
+		if mr.ShadowsEnabled && l.CastShadows {
+			smap = mr.resources.spotShadowMap(l)
+		}
+		er.scatterSpotLight(l, smap)
+	}
+	for _, l := range frame.Scene.DirectionalLights {
+		var smap *graphics.Texture2D
+		if mr.ShadowsEnabled && l.CastShadows {
+			smap = mr.resources.dirShadowMap(l)
+		}
+		er.scatterDirectionalLight(l, smap)
+	}
+	er.endScattering(frame.Source, frame.Depth, frame.Target)
+	return true
+}
+
+type outlineEffect struct {
+	r *Renderer
+}
+
+func (e outlineEffect) Render(frame *PostFrame) bool {
+	if !e.r.Outline.Enabled {
+		return false
+	}
+	e.r.EffectRenderer.RenderOutline(frame.Camera, frame.Source, frame.Depth, frame.Normals, frame.Target, &e.r.Outline)
+	return true
+}
+
+type depthOfFieldEffect struct {
+	r *Renderer
+}
+
+func (e depthOfFieldEffect) Render(frame *PostFrame) bool {
+	c, ok := frame.Camera.(*camera.PerspectiveCamera)
+	if !e.r.DepthOfField || !ok {
+		return false
+	}
+	e.r.EffectRenderer.RenderDepthOfField(c, frame.Source, frame.Depth, frame.Target, e.r.DepthOfFieldMaxRadius)
+	return true
+}
+
+type motionBlurEffect struct {
+	r *Renderer
+}
+
+func (e motionBlurEffect) Render(frame *PostFrame) bool {
+	if !e.r.MotionBlur {
+		return false
+	}
+	var velocity *graphics.Texture2D
+	if e.r.ObjectMotionBlur {
+		velocity = frame.Velocity
+	}
+	e.r.EffectRenderer.RenderMotionBlur(frame.Source, frame.Depth, velocity, frame.Target, frame.ProjectionViewMatrix, frame.PreviousProjectionViewMatrix, e.r.MotionBlurShutter)
+	return true
+}
+
+type blurEffect struct {
+	r *Renderer
+}
+
+func (e blurEffect) Render(frame *PostFrame) bool {
+	if e.r.BlurRadius > 0 {
+		e.r.EffectRenderer.RenderGaussianBlur(frame.Source, frame.Target, e.r.BlurRadius)
+	}
+	return false
+}
+
+type bloomEffect struct {
+	r *Renderer
+}
+
+func (e bloomEffect) Render(frame *PostFrame) bool {
+	if e.r.Bloom && e.r.BloomIntensity > 0 {
+		e.r.EffectRenderer.RenderBloom(frame.Source, e.r.BloomThreshold, e.r.BloomIntensity, e.r.BloomRadius)
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package render
+
+import (
+	"github.com/hersle/gl3d/math"
+	"github.com/hersle/gl3d/object"
+	gomath "math"
+	"sort"
+)
+
+// renderQueue collects the submeshes to draw in a pass and sorts them,
+// so consecutive draws share as much state as possible
+type renderQueue struct {
+	items []drawItem
+}
+
+type drawItem struct {
+	key       uint64
+	meshIndex int
+	subMesh   *object.SubMesh
+}
+
+type queueOrder int
+
+const (
+	// group by mesh, then material, then buffers, then depth
+	stateOrder queueOrder = iota
+
+	// draw nearest first to reject hidden fragments early
+	frontToBackOrder
+)
+
+// drawKey packs the state of a draw into a sort key. The program is the same
+// for all draws in a queue, so it is not part of the key. Ids are truncated to
+// the width of their field, which can only make sorting less effective.
+func drawKey(order queueOrder, mesh, material, buffer int, depth float32) uint64 {
+	msh := uint64(mesh) & 0xffff
+	mtl := uint64(material) & 0xffff
+	buf := uint64(buffer) & 0xff
+
+	// bits of a non-negative float are ordered like the float itself
+	dep := uint64(gomath.Float32bits(math.Max(depth, 0)) >> 8) // 24 bits
+
+	switch order {
+	case frontToBackOrder:
+		return dep<<40 | msh<<24 | mtl<<8 | buf
+	default:
+		return msh<<48 | mtl<<32 | buf<<24 | dep
+	}
+}
+
+func (q *renderQueue) reset() {
+	q.items = q.items[:0]
+}
+
+func (q *renderQueue) add(key uint64, meshIndex int, sm *object.SubMesh) {
+	q.items = append(q.items, drawItem{key, meshIndex, sm})
+}
+
+// sort keeps draws with equal keys in the order they were added,
+// so the draw order does not change between frames
+func (q *renderQueue) sort() {
+	sort.SliceStable(q.items, func(i, j int) bool {
+		return q.items[i].key < q.items[j].key
+	})
+}
@@ -0,0 +1,132 @@
+package render
+
+import (
+	"github.com/hersle/gl3d/material"
+	"github.com/hersle/gl3d/object"
+	"testing"
+)
+
+func TestDrawKeyStateOrder(t *testing.T) {
+	// each key must sort before the next
+	keys := []uint64{
+		drawKey(stateOrder, 0, 5, 5, 100),
+		drawKey(stateOrder, 1, 0, 0, 0),
+		drawKey(stateOrder, 1, 0, 1, 0),
+		drawKey(stateOrder, 1, 1, 0, 0),
+		drawKey(stateOrder, 1, 1, 0, 1),
+		drawKey(stateOrder, 1, 1, 0, 2.5),
+		drawKey(stateOrder, 2, 0, 0, 0),
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] >= keys[i] {
+			t.Errorf("key %d (%x) does not sort before key %d (%x)", i-1, keys[i-1], i, keys[i])
+		}
+	}
+}
+
+func TestDrawKeyFrontToBackOrder(t *testing.T) {
+	keys := []uint64{
+		drawKey(frontToBackOrder, 9, 9, 9, 0),
+		drawKey(frontToBackOrder, 0, 0, 0, 1),
+		drawKey(frontToBackOrder, 0, 0, 1, 1),
+		drawKey(frontToBackOrder, 0, 1, 0, 1),
+		drawKey(frontToBackOrder, 1, 0, 0, 1),
+		drawKey(frontToBackOrder, 0, 0, 0, 1000),
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] >= keys[i] {
+			t.Errorf("key %d (%x) does not sort before key %d (%x)", i-1, keys[i-1], i, keys[i])
+		}
+	}
+}
+
+func TestDrawKeyOverflow(t *testing.T) {
+	tests := []struct {
+		order          queueOrder
+		mesh, material int
+		buffer         int
+		depth          float32
+		sameAs         uint64
+	}{
+		// ids wrap around at the width of their field
+		{stateOrder, 0x10001, 0, 0, 0, drawKey(stateOrder, 1, 0, 0, 0)},
+		{stateOrder, 1, 0x10002, 0, 0, drawKey(stateOrder, 1, 2, 0, 0)},
+		{stateOrder, 1, 0, 0x103, 0, drawKey(stateOrder, 1, 0, 3, 0)},
+		{frontToBackOrder, 0x10001, 0x10002, 0x103, 0, drawKey(frontToBackOrder, 1, 2, 3, 0)},
+
+		// negative depth counts as zero
+		{stateOrder, 1, 0, 0, -10, drawKey(stateOrder, 1, 0, 0, 0)},
+		{frontToBackOrder, 1, 0, 0, -10, drawKey(frontToBackOrder, 1, 0, 0, 0)},
+	}
+	for i, test := range tests {
+		key := drawKey(test.order, test.mesh, test.material, test.buffer, test.depth)
+		if key != test.sameAs {
+			t.Errorf("test %d: got key %x, expected %x", i, key, test.sameAs)
+		}
+	}
+
+	// overflowing fields must not leak into the first field
+	key := drawKey(stateOrder, 0, 0xffffff, 0xffffff, 1e30)
+	if key>>48 != 0 {
+		t.Errorf("fields overflow into the mesh: %x", key)
+	}
+	key = drawKey(frontToBackOrder, 0xffffff, 0xffffff, 0xffffff, 0)
+	if key>>40 != 0 {
+		t.Errorf("fields overflow into the depth: %x", key)
+	}
+}
+
+func TestRenderQueueStateChanges(t *testing.T) {
+	// three meshes with submeshes alternating between two materials,
+	// at depths that disagree with the mesh order
+	materials := make([]material.Material, 2)
+	subMeshes := make([]object.SubMesh, 12)
+	var q renderQueue
+	for i := range subMeshes {
+		mesh := i / 4
+		mtl := i % 2
+		subMeshes[i].Mtl = &materials[mtl]
+		q.add(drawKey(stateOrder, mesh, mtl, 0, float32(12-i)), mesh, &subMeshes[i])
+	}
+	q.sort()
+
+	// count changes like MeshRenderer.renderMeshes does
+	meshChanges, mtlChanges := 0, 0
+	lastMesh := -1
+	var lastMtl *material.Material
+	for _, item := range q.items {
+		if item.meshIndex != lastMesh {
+			meshChanges++
+			lastMesh = item.meshIndex
+		}
+		if item.subMesh.Mtl != lastMtl {
+			mtlChanges++
+			lastMtl = item.subMesh.Mtl
+		}
+	}
+
+	// each mesh is set once, and each of its materials once
+	if meshChanges != 3 {
+		t.Errorf("got %d mesh changes, expected 3", meshChanges)
+	}
+	if mtlChanges != 6 {
+		t.Errorf("got %d material changes, expected 6", mtlChanges)
+	}
+}
+
+func TestRenderQueueStableSort(t *testing.T) {
+	var q renderQueue
+	subMeshes := make([]object.SubMesh, 4)
+	q.add(1, 0, &subMeshes[0])
+	q.add(0, 1, &subMeshes[1])
+	q.add(1, 2, &subMeshes[2])
+	q.add(0, 3, &subMeshes[3])
+	q.sort()
+
+	expected := []int{1, 3, 0, 2}
+	for i, item := range q.items {
+		if item.meshIndex != expected[i] {
+			t.Errorf("item %d has mesh %d, expected %d", i, item.meshIndex, expected[i])
+		}
+	}
+}
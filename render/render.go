@@ -7,6 +7,88 @@ import (
 	"github.com/hersle/gl3d/scene"
 )
 
+type ToneMapOperator int
+
+// must match the constants in the tone mapping shader
+const (
+	NoToneMapping ToneMapOperator = iota
+	ReinhardToneMapping
+	ACESToneMapping
+)
+
+type Antialiasing int
+
+const (
+	NoAntialiasing Antialiasing = iota
+	MultisampleAntialiasing
+	FastApproximateAntialiasing
+	TemporalAntialiasing
+)
+
+const multisampleCount = 4
+
+type FogMode int
+
+// must match the constants in the fog shader
+const (
+	NoFog FogMode = iota
+	LinearFog
+	ExponentialFog
+	SquaredExponentialFog
+)
+
+// Reflections are screen space reflections on specular surfaces
+type Reflections struct {
+	Enabled bool
+	Intensity float32
+	MaxSteps int
+	RefineSteps int // binary search steps after the ray hits
+	StepSize float32 // in view space
+	Thickness float32 // of surfaces rays can pass behind
+	Debug bool // show only the reflections
+}
+
+// Outline draws lines along the edges of objects for a stylized look
+type Outline struct {
+	Enabled bool
+	Color math.Vec3
+	Thickness float32 // in pixels
+	DepthThreshold float32 // relative depth difference that makes an edge
+	NormalThreshold float32
+}
+
+// VolumetricLight is light from spot and directional lights scattered toward
+// the camera by the air, so their shadows cast visible shafts
+type VolumetricLight struct {
+	Enabled bool
+	Density float32 // scattering per unit distance
+	Anisotropy float32 // in (-1, +1), positive scatters more light forward
+	Steps int // along each view ray
+	MaxDistance float32
+}
+
+type Fog struct {
+	Enabled bool
+	Mode FogMode
+	Color math.Vec3
+	Density float32 // for the exponential modes
+	Start, End float32 // distances for the linear mode
+
+	// exponential height fog, in addition to the mode,
+	// with density HeightDensity * exp(-HeightFalloff * (y - Height))
+	HeightDensity float32
+	HeightFalloff float32
+	Height float32
+
+	SkyboxTint float32 // fraction of the fog color taken from the skybox
+}
+
+func (f *Fog) enabled() bool {
+	return f.Enabled && (f.Mode != NoFog || f.HeightDensity > 0)
+}
+
+const jitterSampleCount = 8
+
 // TODO: redesign attr/uniform access system?
 type Renderer struct {
 	MeshRenderer   *MeshRenderer
@@ -18,17 +100,62 @@ type Renderer struct {
 	sceneRenderTarget      *graphics.Texture2D
 	sceneRenderTarget2     *graphics.Texture2D
 	sceneDepthRenderTarget *graphics.Texture2D
+	displayRenderTarget    *graphics.Texture2D // tone mapped scene
+	displayRenderTarget2   *graphics.Texture2D
+
+	multisampleRenderTarget      *graphics.Texture2DMultisample
+	multisampleDepthRenderTarget *graphics.Texture2DMultisample
+
+	velocityRenderTarget *graphics.Texture2D
+	surfaceRenderTarget *graphics.Texture2D
+	normalRenderTarget *graphics.Texture2D
+	frame int
+
+	// camera transformations without jitter, for effects that track motion
+	projViewMat math.Mat4
+	previousProjViewMat math.Mat4
+	previousValid bool
 
 	overlayRenderTarget *graphics.Texture2D
 
-	Fog bool
+	// applied in order after the scene is rendered
+	PostEffects []PostEffect
+
+	Fog Fog
+	VolumetricLight VolumetricLight
+	Reflections Reflections
+	Outline Outline
 	BlurRadius float32
+	DepthOfField bool
+	DepthOfFieldMaxRadius float32 // in pixels
+	MotionBlur bool
+	ObjectMotionBlur bool // also blur moving meshes, not just camera motion
+	MotionBlurShutter float32 // fraction of the frame time the shutter is open
+	Antialiasing Antialiasing
+	TemporalBlend float32 // weight of the current frame
+
+	Bloom bool
+	BloomThreshold float32
+	BloomIntensity float32
+	BloomRadius float32
+
+	// color grading after tone mapping, blending from ColorLUT to ColorLUT2
+	ColorLUT *ColorLUT
+	ColorLUT2 *ColorLUT
+	ColorLUTBlend float32
+
+	ToneMapping ToneMapOperator
+	Exposure float32
+	AutoExposure bool
+	ExposureAdaptation float32 // per frame
 }
 
 func NewRenderer() (*Renderer, error) {
 	var r Renderer
 
 	r.MeshRenderer, _ = NewMeshRenderer()
+	r.MeshRenderer.projViewMat = &r.projViewMat
+	r.MeshRenderer.previousProjViewMat = &r.previousProjViewMat
 	r.SkyboxRenderer = NewSkyboxRenderer()
 	r.TextRenderer = NewTextRenderer()
 	r.ArrowRenderer = NewArrowRenderer()
@@ -37,27 +164,190 @@ func NewRenderer() (*Renderer, error) {
 	w, h := 1920, 1080
 	w, h = w/1, h/1
 
-	r.sceneRenderTarget = graphics.NewTexture2D(graphics.ColorTexture, graphics.NearestFilter, graphics.EdgeClampWrap, w, h, false)
-	r.sceneRenderTarget2 = graphics.NewTexture2D(graphics.ColorTexture, graphics.NearestFilter, graphics.EdgeClampWrap, w, h, false)
+	// floating point, so bright lights add up without clipping
+	r.sceneRenderTarget = graphics.NewColorTexture2D(graphics.NearestFilter, graphics.EdgeClampWrap, w, h, 4, 16, true, false)
+	r.sceneRenderTarget2 = graphics.NewColorTexture2D(graphics.NearestFilter, graphics.EdgeClampWrap, w, h, 4, 16, true, false)
 	r.sceneDepthRenderTarget = graphics.NewTexture2D(graphics.DepthTexture, graphics.NearestFilter, graphics.EdgeClampWrap, w, h, false)
+	r.displayRenderTarget = graphics.NewTexture2D(graphics.ColorTexture, graphics.LinearFilter, graphics.EdgeClampWrap, w, h, false)
+	r.displayRenderTarget2 = graphics.NewTexture2D(graphics.ColorTexture, graphics.LinearFilter, graphics.EdgeClampWrap, w, h, false)
+
+	r.multisampleRenderTarget = graphics.NewColorTexture2DMultisample(w, h, multisampleCount, 4, 16, true)
+	r.multisampleDepthRenderTarget = graphics.NewDepthTexture2DMultisample(w, h, multisampleCount)
+
+	r.velocityRenderTarget = graphics.NewColorTexture2D(graphics.NearestFilter, graphics.EdgeClampWrap, w, h, 2, 16, true, false)
+	r.surfaceRenderTarget = graphics.NewColorTexture2D(graphics.NearestFilter, graphics.EdgeClampWrap, w, h, 4, 16, true, false)
+	r.normalRenderTarget = graphics.NewColorTexture2D(graphics.NearestFilter, graphics.EdgeClampWrap, w, h, 4, 16, true, false)
 
 	r.overlayRenderTarget = graphics.NewTexture2D(graphics.ColorTexture, graphics.NearestFilter, graphics.EdgeClampWrap, w, h, false)
 
+	r.TemporalBlend = 0.1
+
+	r.Reflections.Intensity = 1
+	r.Reflections.MaxSteps = 64
+	r.Reflections.RefineSteps = 8
+	r.Reflections.StepSize = 0.1
+	r.Reflections.Thickness = 0.5
+
+	r.Outline.Color = math.Vec3{0, 0, 0}
+	r.Outline.Thickness = 1.5
+	r.Outline.DepthThreshold = 0.1
+	r.Outline.NormalThreshold = 0.5
+
+	r.Fog.Enabled = false
+	r.Fog.Mode = ExponentialFog
+	r.Fog.Color = math.Vec3{1, 1, 1}
+	r.Fog.Density = 0.01
+	r.Fog.Start = 0
+	r.Fog.End = 100
+	r.Fog.HeightFalloff = 0.5
+
+	r.VolumetricLight.Density = 0.05
+	r.VolumetricLight.Anisotropy = 0.5
+	r.VolumetricLight.Steps = 32
+	r.VolumetricLight.MaxDistance = 100
+
+	r.DepthOfFieldMaxRadius = 12
+
+	r.ObjectMotionBlur = true
+	r.MotionBlurShutter = 0.5
+
+	r.PostEffects = []PostEffect{reflectionsEffect{&r}, fogEffect{&r}, volumetricLightEffect{&r}, outlineEffect{&r}, depthOfFieldEffect{&r}, motionBlurEffect{&r}, blurEffect{&r}, bloomEffect{&r}}
+
+	r.Bloom = false
+	r.BloomThreshold = 1
+	r.BloomIntensity = 0.5
+	r.BloomRadius = 2
+
+	r.ToneMapping = NoToneMapping // clamp like before HDR rendering
+	r.Exposure = 1
+	r.AutoExposure = false
+	r.ExposureAdaptation = 0.05
+
 	return &r, nil
 }
 
 func (r *Renderer) RenderScene(s *scene.Scene, c camera.Camera) {
-	if s.Skybox != nil {
-		r.SkyboxRenderer.Render(s.Skybox, c, r.sceneRenderTarget)
+	r.jitter(c)
+	r.trackCamera(c)
+
+	if r.surfaceEnabled() {
+		r.MeshRenderer.surfaceTarget = r.surfaceRenderTarget
+	} else {
+		r.MeshRenderer.surfaceTarget = nil
+	}
+	if !r.normalsEnabled() {
+		r.MeshRenderer.normalTarget = nil
+	} else if r.surfaceEnabled() && !r.MeshRenderer.MaterialNormalEnabled {
+		r.MeshRenderer.normalTarget = r.surfaceRenderTarget // same normals
+	} else {
+		r.MeshRenderer.normalTarget = r.normalRenderTarget
+	}
+
+	if r.Antialiasing == MultisampleAntialiasing {
+		if s.Skybox != nil {
+			r.SkyboxRenderer.Render(s.Skybox, c, r.multisampleRenderTarget)
+		}
+		r.MeshRenderer.RenderMultisample(s, c, r.multisampleRenderTarget, r.multisampleDepthRenderTarget, r.sceneDepthRenderTarget)
+		r.multisampleRenderTarget.Resolve(r.sceneRenderTarget)
+	} else {
+		if s.Skybox != nil {
+			r.SkyboxRenderer.Render(s.Skybox, c, r.sceneRenderTarget)
+			if r.MeshRenderer.velocityTarget != nil {
+				r.SkyboxRenderer.RenderVelocity(c, &r.projViewMat, &r.previousProjViewMat, r.velocityRenderTarget)
+			}
+		}
+		r.MeshRenderer.Render(s, c, r.sceneRenderTarget, r.sceneDepthRenderTarget)
+	}
+
+	if r.Antialiasing == TemporalAntialiasing {
+		r.EffectRenderer.RenderTemporalAntialiasing(r.sceneRenderTarget, r.velocityRenderTarget, r.TemporalBlend)
+	} else {
+		r.EffectRenderer.resetHistory()
 	}
 
-	r.MeshRenderer.Render(s, c, r.sceneRenderTarget, r.sceneDepthRenderTarget)
-	if r.Fog {
-		r.EffectRenderer.RenderFog(c, r.sceneDepthRenderTarget, r.sceneRenderTarget)
+	r.renderPostEffects(s, c)
+
+	r.previousProjViewMat = r.projViewMat
+	r.previousValid = true
+}
+
+func (r *Renderer) trackCamera(c camera.Camera) {
+	r.projViewMat.Identity()
+	r.projViewMat.Mult(unjitteredProjectionMatrix(c))
+	r.projViewMat.Mult(c.ViewMatrix())
+
+	// without a previous frame, pretend nothing moved
+	if !r.previousValid {
+		r.previousProjViewMat = r.projViewMat
 	}
-	if r.BlurRadius > 0 {
-		r.EffectRenderer.RenderGaussianBlur(r.sceneRenderTarget, r.sceneRenderTarget2, r.BlurRadius)
+}
+
+func unjitteredProjectionMatrix(c camera.Camera) *math.Mat4 {
+	if pc, ok := c.(*camera.PerspectiveCamera); ok {
+		return pc.UnjitteredProjectionMatrix()
 	}
+	return c.ProjectionMatrix()
+}
+
+// surfaceEnabled tells if the mesh renderer should write surface properties
+func (r *Renderer) surfaceEnabled() bool {
+	// the surface target can not be attached along with multisampled depth
+	return r.Reflections.Enabled && r.Antialiasing != MultisampleAntialiasing
+}
+
+// normalsEnabled tells if the mesh renderer should write geometric normals,
+// which outlines follow instead of the details of normal maps
+func (r *Renderer) normalsEnabled() bool {
+	return r.Outline.Enabled && r.Antialiasing != MultisampleAntialiasing
+}
+
+// velocityEnabled tells if the depth pass should write per pixel velocities
+func (r *Renderer) velocityEnabled() bool {
+	if r.Antialiasing == TemporalAntialiasing {
+		return true
+	}
+
+	// the velocity target can not be attached along with multisampled depth
+	return r.MotionBlur && r.ObjectMotionBlur && r.Antialiasing != MultisampleAntialiasing
+}
+
+// jitter offsets the camera by a different subpixel amount every frame
+// for temporal antialiasing
+func (r *Renderer) jitter(c camera.Camera) {
+	pc, ok := c.(*camera.PerspectiveCamera)
+
+	if r.velocityEnabled() {
+		r.MeshRenderer.velocityTarget = r.velocityRenderTarget
+	} else {
+		r.MeshRenderer.velocityTarget = nil
+	}
+
+	if r.Antialiasing != TemporalAntialiasing {
+		if ok {
+			pc.SetJitter(math.Vec2{0, 0})
+		}
+		return
+	}
+
+	if ok {
+		i := r.frame%jitterSampleCount + 1
+		x := 2 * (halton(i, 2) - 0.5) / float32(r.sceneRenderTarget.Width())
+		y := 2 * (halton(i, 3) - 0.5) / float32(r.sceneRenderTarget.Height())
+		pc.SetJitter(math.Vec2{x, y})
+	}
+	r.frame++
+}
+
+// halton returns element i of the low-discrepancy Halton sequence in (0, 1)
+func halton(i, base int) float32 {
+	f := float32(1)
+	x := float32(0)
+	for i > 0 {
+		f /= float32(base)
+		x += f * float32(i%base)
+		i /= base
+	}
+	return x
 }
 
 func (r *Renderer) RenderText(tl math.Vec2, text string, height float32, just Justification) {
@@ -82,11 +372,34 @@ func (r *Renderer) Clear() {
 	r.sceneRenderTarget.Clear(math.Vec4{0, 0, 0, 0})
 	r.sceneRenderTarget2.Clear(math.Vec4{0, 0, 0, 0})
 	r.sceneDepthRenderTarget.Clear(math.Vec4{1, 1, 1, 1})
+	if r.Antialiasing == MultisampleAntialiasing {
+		r.multisampleRenderTarget.Clear(math.Vec4{0, 0, 0, 0})
+		r.multisampleDepthRenderTarget.Clear(math.Vec4{1, 1, 1, 1})
+	}
+	if r.velocityEnabled() {
+		r.velocityRenderTarget.Clear(math.Vec4{0, 0, 0, 0})
+	}
+	if r.surfaceEnabled() {
+		r.surfaceRenderTarget.Clear(math.Vec4{0, 0, 0, 0})
+	}
+	if r.normalsEnabled() {
+		r.normalRenderTarget.Clear(math.Vec4{0, 0, 0, 0})
+	}
 	r.overlayRenderTarget.Clear(math.Vec4{0, 0, 0, 0})
 }
 
 func (r *Renderer) Render() {
-	r.sceneRenderTarget.Display(graphics.NoBlending)
+	r.EffectRenderer.RenderToneMapping(r.sceneRenderTarget, r.displayRenderTarget, r.ToneMapping, r.Exposure, r.AutoExposure, r.ExposureAdaptation)
+	if r.ColorLUT != nil || r.ColorLUT2 != nil {
+		r.EffectRenderer.RenderColorGrading(r.displayRenderTarget, r.displayRenderTarget2, r.ColorLUT, r.ColorLUT2, r.ColorLUTBlend)
+		r.displayRenderTarget, r.displayRenderTarget2 = r.displayRenderTarget2, r.displayRenderTarget
+	}
+	if r.Antialiasing == FastApproximateAntialiasing {
+		r.EffectRenderer.RenderFXAA(r.displayRenderTarget, r.displayRenderTarget2)
+		r.displayRenderTarget2.Display(graphics.NoBlending)
+	} else {
+		r.displayRenderTarget.Display(graphics.NoBlending)
+	}
 	r.overlayRenderTarget.Display(graphics.AlphaBlending)
 }
 
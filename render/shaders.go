@@ -0,0 +1,34 @@
+package render
+
+import (
+	"embed"
+	"github.com/hersle/gl3d/graphics"
+	"io/fs"
+	"os"
+)
+
+// shader sources are compiled into the binary, so it runs from any directory
+//go:embed shaders/*.glsl
+var embeddedShaders embed.FS
+
+// ShaderDir is a directory to read shaders from instead of those embedded in
+// the binary, if not empty
+var ShaderDir string
+
+// shaderFS returns the file system to read shaders from,
+// preferring ShaderDir during development
+func shaderFS() fs.FS {
+	if ShaderDir != "" {
+		return os.DirFS(ShaderDir)
+	}
+
+	fsys, err := fs.Sub(embeddedShaders, "shaders")
+	if err != nil {
+		panic(err)
+	}
+	return fsys
+}
+
+func readProgram(vFile, fFile, gFile string, defines ...string) *graphics.Program {
+	return graphics.ReadProgramFS(shaderFS(), vFile, fFile, gFile, defines...)
+}
@@ -0,0 +1,14 @@
+#version 450
+
+uniform sampler2D logLuminanceMap;
+uniform float adaptation; // fraction to move towards the new luminance
+
+out vec4 fragColor;
+
+void main() {
+	int level = textureQueryLevels(logLuminanceMap) - 1;
+	float luminance = exp(textureLod(logLuminanceMap, vec2(0.5), level).r);
+
+	// blended with the old luminance
+	fragColor = vec4(luminance, 0, 0, adaptation);
+}
@@ -0,0 +1,24 @@
+#version 450
+
+in vec2 texCoord;
+
+uniform sampler2D inTexture;
+uniform float threshold;
+
+out vec4 fragColor;
+
+void main() {
+	// average the 2x2 source pixels explicitly, since the source
+	// may use nearest filtering
+	ivec2 p = 2 * ivec2(gl_FragCoord.xy);
+	vec3 color = texelFetch(inTexture, p, 0).rgb;
+	color += texelFetch(inTexture, p + ivec2(1, 0), 0).rgb;
+	color += texelFetch(inTexture, p + ivec2(0, 1), 0).rgb;
+	color += texelFetch(inTexture, p + ivec2(1, 1), 0).rgb;
+	color *= 0.25;
+	float luminance = dot(color, vec3(0.2126, 0.7152, 0.0722));
+
+	// keep the part of the color that exceeds the threshold
+	float factor = max(luminance - threshold, 0) / max(luminance, 0.0001);
+	fragColor = vec4(factor * color, 1);
+}
@@ -0,0 +1,23 @@
+#version 450
+
+in vec2 texCoord;
+
+uniform sampler2D inTexture;
+uniform sampler3D lut1;
+uniform sampler3D lut2;
+uniform float blend; // from lut1 to lut2
+
+out vec4 fragColor;
+
+vec3 lookup(sampler3D lut, vec3 color) {
+	// sample the centers of the outermost texels at 0 and 1
+	float size = float(textureSize(lut, 0).x);
+	vec3 coord = (color * (size - 1.0) + 0.5) / size;
+	return texture(lut, coord).rgb;
+}
+
+void main() {
+	vec4 color = texture(inTexture, texCoord);
+	vec3 rgb = clamp(color.rgb, 0.0, 1.0);
+	fragColor = vec4(mix(lookup(lut1, rgb), lookup(lut2, rgb), blend), color.a);
+}
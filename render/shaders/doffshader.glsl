@@ -0,0 +1,70 @@
+#version 450
+
+in vec2 texCoord;
+
+uniform sampler2D inTexture;
+uniform sampler2D depthMap;
+uniform mat4 invProjectionMatrix;
+
+uniform float focalDistance;
+uniform float focalLength;
+uniform float aperture; // diameter
+uniform float sensorHeight;
+uniform bool autoFocus;
+uniform float maxRadius; // in pixels
+
+out vec4 fragColor;
+
+#define SAMPLE_COUNT 48
+#define GOLDEN_ANGLE 2.39996323
+
+float viewDepth(vec2 texCoord) {
+	float depth = texture(depthMap, texCoord).r;
+	vec4 ndcPosition = vec4(-1.0 + 2.0 * texCoord, -1.0 + 2.0 * depth, 1);
+	vec4 viewPosition = invProjectionMatrix * ndcPosition;
+	return -viewPosition.z / viewPosition.w;
+}
+
+// radius in pixels of the circle a point at the depth is blurred to
+float confusionRadius(float depth, float focus) {
+	float diameter = aperture * focalLength * abs(depth - focus) / (depth * (focus - focalLength));
+	float pixels = diameter / 2 / sensorHeight * textureSize(inTexture, 0).y;
+	return min(pixels, maxRadius);
+}
+
+void main() {
+	float focus = focalDistance;
+	if (autoFocus) {
+		focus = viewDepth(vec2(0.5, 0.5));
+	}
+	focus = max(focus, 2 * focalLength);
+
+	float depth = viewDepth(texCoord);
+	float radius = confusionRadius(depth, focus);
+
+	vec2 texel = 1.0 / vec2(textureSize(inTexture, 0));
+	vec3 sum = texture(inTexture, texCoord).rgb;
+	float weightSum = 1;
+
+	// gather from a spiral of samples spread evenly over the largest circle,
+	// counting those whose circle of confusion covers this pixel
+	for (int i = 1; i < SAMPLE_COUNT; i++) {
+		float dist = maxRadius * sqrt(float(i) / SAMPLE_COUNT);
+		float angle = i * GOLDEN_ANGLE;
+		vec2 sampleTexCoord = texCoord + dist * vec2(cos(angle), sin(angle)) * texel;
+
+		float sampleDepth = viewDepth(sampleTexCoord);
+		float sampleRadius = confusionRadius(sampleDepth, focus);
+
+		// keep the background from bleeding onto sharper foreground
+		if (sampleDepth > depth) {
+			sampleRadius = min(sampleRadius, radius);
+		}
+
+		float weight = clamp(sampleRadius - dist + 1, 0, 1);
+		sum += weight * texture(inTexture, sampleTexCoord).rgb;
+		weightSum += weight;
+	}
+
+	fragColor = vec4(sum / weightSum, 1);
+}
@@ -3,23 +3,63 @@
 in vec2 fragPosition;
 
 uniform sampler2D depthTexture;
+uniform samplerCube skybox;
 
 uniform mat4 invProjectionMatrix;
+uniform mat4 invViewMatrix;
 
-uniform float cameraFar;
+// must match the constants in render.FogMode
+#define NO_FOG 0
+#define LINEAR_FOG 1
+#define EXP_FOG 2
+#define EXP2_FOG 3
+
+uniform int mode;
+uniform vec3 color;
+uniform float density;
+uniform float start;
+uniform float end;
+uniform float heightDensity;
+uniform float heightFalloff;
+uniform float height;
+uniform float skyboxTint;
 
 out vec4 fragColor;
 
 void main() {
-	vec3 fogColor = vec3(1, 1, 1);
-
 	float depth = texture(depthTexture, 0.5 + 0.5 * fragPosition).r; // [0, 1]
 	vec4 ndcPosition = vec4(fragPosition, -1.0 + 2.0 * depth, 1); // [-1, +1]
 	vec4 clipPosition = invProjectionMatrix * ndcPosition;
 	vec3 camPosition = clipPosition.xyz / clipPosition.w;
+	float dist = length(camPosition);
+
+	float fog = 0; // fraction of the light that is scattered away
+	if (mode == LINEAR_FOG) {
+		fog = clamp((dist - start) / max(end - start, 0.0001), 0, 1);
+	} else if (mode == EXP_FOG) {
+		fog = 1 - exp(-density * dist);
+	} else if (mode == EXP2_FOG) {
+		fog = 1 - exp(-pow(density * dist, 2));
+	}
+
+	// integrate the density heightDensity * exp(-heightFalloff * (y - height))
+	// along the view ray
+	if (heightDensity > 0) {
+		vec3 worldCamera = vec3(invViewMatrix * vec4(0, 0, 0, 1));
+		vec3 worldPosition = vec3(invViewMatrix * vec4(camPosition, 1));
+		float dy = worldPosition.y - worldCamera.y;
+		float amount = heightDensity * exp(-heightFalloff * (worldCamera.y - height)) * dist;
+		if (abs(heightFalloff * dy) > 0.0001) {
+			amount *= (1 - exp(-heightFalloff * dy)) / (heightFalloff * dy);
+		}
+		fog = 1 - (1 - fog) * exp(-amount);
+	}
 
-	float fogFactor = 1 - length(camPosition) / (cameraFar * 0.5);
-	fogFactor = pow(2.71828, -(fogFactor));
+	vec3 fogColor = color;
+	if (skyboxTint > 0) {
+		vec3 direction = mat3(invViewMatrix) * camPosition;
+		fogColor = mix(color, texture(skybox, direction).rgb, skyboxTint);
+	}
 
-	fragColor = vec4(fogColor, fogFactor);
+	fragColor = vec4(fogColor, fog);
 }
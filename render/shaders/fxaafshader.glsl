@@ -0,0 +1,53 @@
+#version 450
+
+// fast approximate antialiasing, after the FXAA algorithm by Timothy Lottes
+
+in vec2 texCoord;
+
+uniform sampler2D inTexture;
+
+out vec4 fragColor;
+
+const float spanMax = 8.0;
+const float reduceMul = 1.0 / 8.0;
+const float reduceMin = 1.0 / 128.0;
+
+float luma(vec3 color) {
+	return dot(color, vec3(0.299, 0.587, 0.114));
+}
+
+void main() {
+	vec2 texel = 1.0 / vec2(textureSize(inTexture, 0));
+
+	vec4 colorM = texture(inTexture, texCoord);
+	float lumaM = luma(colorM.rgb);
+	float lumaNW = luma(texture(inTexture, texCoord + vec2(-1, +1) * texel).rgb);
+	float lumaNE = luma(texture(inTexture, texCoord + vec2(+1, +1) * texel).rgb);
+	float lumaSW = luma(texture(inTexture, texCoord + vec2(-1, -1) * texel).rgb);
+	float lumaSE = luma(texture(inTexture, texCoord + vec2(+1, -1) * texel).rgb);
+
+	float lumaMin = min(lumaM, min(min(lumaNW, lumaNE), min(lumaSW, lumaSE)));
+	float lumaMax = max(lumaM, max(max(lumaNW, lumaNE), max(lumaSW, lumaSE)));
+
+	// blur along the edge, i.e. normal to the luma gradient
+	vec2 dir;
+	dir.x = -((lumaNW + lumaNE) - (lumaSW + lumaSE));
+	dir.y = +((lumaNW + lumaSW) - (lumaNE + lumaSE));
+
+	float dirReduce = max((lumaNW + lumaNE + lumaSW + lumaSE) * 0.25 * reduceMul, reduceMin);
+	float dirScale = 1.0 / (min(abs(dir.x), abs(dir.y)) + dirReduce);
+	dir = clamp(dir * dirScale, vec2(-spanMax), vec2(+spanMax)) * texel;
+
+	vec3 colorA = 0.5 * (texture(inTexture, texCoord + dir * (1.0 / 3.0 - 0.5)).rgb +
+	                     texture(inTexture, texCoord + dir * (2.0 / 3.0 - 0.5)).rgb);
+	vec3 colorB = 0.5 * colorA + 0.25 * (texture(inTexture, texCoord + dir * -0.5).rgb +
+	                                     texture(inTexture, texCoord + dir * +0.5).rgb);
+
+	// the wider blur may reach across other edges
+	float lumaB = luma(colorB);
+	if (lumaB < lumaMin || lumaB > lumaMax) {
+		fragColor = vec4(colorA, colorM.a);
+	} else {
+		fragColor = vec4(colorB, colorM.a);
+	}
+}
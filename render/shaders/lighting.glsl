@@ -0,0 +1,153 @@
+// lighting functions shared by the mesh shaders
+
+// perturb the tangent space normal (0, 0, 1) by the gradient of a bump map
+vec3 bumpMapNormal(sampler2D bumpMap, vec2 texCoord, int width, int height) {
+	float dx = 1.0 / width;
+	float dy = 1.0 / height;
+	float z1 = texture(bumpMap, vec2(texCoord.x-dx, texCoord.y)).r;
+	float z2 = texture(bumpMap, vec2(texCoord.x+dx, texCoord.y)).r;
+	float dzdx = (z2-z1) * 10.0;
+	z1 = texture(bumpMap, vec2(texCoord.x, texCoord.y-dy)).r;
+	z2 = texture(bumpMap, vec2(texCoord.x, texCoord.y+dy)).r;
+	float dzdy = (z2-z1) * 10.0;
+	return normalize(vec3(dzdx, dzdy, 2));
+}
+
+// fade smoothly from 1 to 0 at the distance range, unless it is 0
+float rangeWindow(float d, float range) {
+	if (range <= 0) {
+		return 1;
+	}
+	float window = clamp(1 - pow(d / range, 4), 0, 1);
+	return window * window;
+}
+
+// lights are treated as spheres of this radius, so that inverse square
+// attenuation 1/max(d^2, r^2) stays finite close to them
+const float minLightDistance = 0.1;
+
+// 1 / (constant + linear*d + quadratic*d^2) for the attenuation terms in
+// (constant, linear, quadratic), smoothly cut off to 0 at range unless it is 0
+float attenuationFactor(vec3 attenuation, float range, vec3 lightToVertex) {
+	float d = max(length(lightToVertex), minLightDistance);
+	float factor = 1 / max(attenuation.x + attenuation.y * d + attenuation.z * d * d, 0.0001);
+	return factor * rangeWindow(d, range);
+}
+
+// fade a spot light smoothly from its inner to its outer cone
+float spotFactor(vec3 direction, vec3 lightToVertex, float cosInner, float cosOuter) {
+	float cosAngle = dot(normalize(direction), normalize(lightToVertex));
+	float t = clamp((cosAngle - cosOuter) / max(cosInner - cosOuter, 0.0001), 0, 1);
+	return t * t * (3 - 2 * t);
+}
+
+// blend a material color with its texture according to the texture alpha
+vec3 materialColor(vec3 color, sampler2D map, vec2 texCoord) {
+	vec4 tex = texture(map, texCoord);
+	return (1 - tex.a) * color + tex.a * tex.rgb;
+}
+
+#if defined(TOON)
+uniform int toonBands;
+
+// quantize lighting into bands for cel shading
+float toonBand(float factor) {
+	return ceil(factor * toonBands) / toonBands;
+}
+#endif
+
+float diffuseFactor(vec3 normal, vec3 lightToVertex) {
+	float factor = max(dot(normal, normalize(-lightToVertex)), 0);
+	#if defined(TOON)
+	factor = toonBand(factor);
+	#endif
+	return factor;
+}
+
+float specularFactor(vec3 normal, vec3 lightToVertex, vec3 cameraToVertex, float shine) {
+	vec3 reflection = normalize(reflect(lightToVertex, normal));
+	bool facing = dot(normal, lightToVertex) < 0;
+	float factor = pow(max(dot(reflection, -normalize(cameraToVertex)), 0), shine) * (facing ? 1 : 0);
+	#if defined(TOON)
+	factor = step(0.5, factor); // a hard highlight
+	#endif
+	return factor;
+}
+
+#if defined(AREA)
+// must match the constants in render/ltc.go
+#define LTC_SIZE 32.0
+#define MAX_AREA_POINTS 16
+
+#define PI 3.14159265
+
+// integral of the cosine over the edge from v1 to v2 of a polygon of unit
+// vectors, as a vector (with a rational fit of theta / sin(theta))
+vec3 ltcEdge(vec3 v1, vec3 v2) {
+	float x = dot(v1, v2);
+	float y = abs(x);
+	float a = 0.8543985 + (0.4965155 + 0.0145206 * y) * y;
+	float b = 3.4175940 + (4.1616724 + y) * y;
+	float v = a / b;
+	float thetaSinTheta = x > 0 ? v : 0.5 * inversesqrt(max(1 - x * x, 1e-7)) - v;
+	return cross(v1, v2) * thetaSinTheta;
+}
+
+// form factor of a polygon seen from position through the linear transformation
+// minv, which maps the lobe to integrate to a cosine around the z axis
+float ltcIntegrate(mat3 minv, vec3 position, vec3 points[MAX_AREA_POINTS], int count, bool behind) {
+	vec3 first = normalize(minv * (points[0] - position));
+	vec3 previous = first;
+	vec3 sum = vec3(0, 0, 0);
+	for (int i = 1; i < count; i++) {
+		vec3 current = normalize(minv * (points[i] - position));
+		sum += ltcEdge(previous, current);
+		previous = current;
+	}
+	sum += ltcEdge(previous, first);
+	sum /= 2 * PI;
+
+	// the winding is reversed from behind
+	if (behind) {
+		sum = -sum;
+	}
+
+	// approximate clipping to the horizon with that of a sphere
+	// with the same vector form factor
+	float len = length(sum);
+	return max((len * len + sum.z) / (len + 1), 0);
+}
+
+// light from a polygonal area light with unit radiance, with the diffuse
+// lobe integrated exactly and the specular lobe by a transformed cosine
+vec3 areaLighting(vec3 normal, vec3 toCamera, vec3 position, vec3 points[MAX_AREA_POINTS], int count, bool behind,
+                  vec3 diffuseColor, vec3 specularColor, float shine, sampler2D matrixMap, sampler2D amplitudeMap) {
+	// frame with the camera in the xz plane
+	vec3 tangent = toCamera - normal * dot(toCamera, normal);
+	if (dot(tangent, tangent) < 1e-8) {
+		tangent = cross(normal, abs(normal.x) < 0.9 ? vec3(1, 0, 0) : vec3(0, 1, 0));
+	}
+	tangent = normalize(tangent);
+	vec3 bitangent = cross(normal, tangent);
+	mat3 toLocal = transpose(mat3(tangent, bitangent, normal));
+
+	// roughly match the Phong exponent with the GGX roughness sqrt(alpha)
+	float roughness = pow(2 / (shine + 2), 0.25);
+	float cosTheta = clamp(dot(normal, toCamera), 0, 1);
+	vec2 uv = vec2(roughness, sqrt(1 - cosTheta)) * (LTC_SIZE - 1) / LTC_SIZE + 0.5 / LTC_SIZE;
+	vec4 m = texture(matrixMap, uv);
+	vec4 amplitude = texture(amplitudeMap, uv);
+	mat3 minv = mat3(vec3(m.x, 0, m.y), vec3(0, 1, 0), vec3(m.z, 0, m.w));
+
+	float diffuse = ltcIntegrate(toLocal, position, points, count, behind);
+	float specular = ltcIntegrate(minv * toLocal, position, points, count, behind);
+	#if defined(TOON)
+	diffuse = toonBand(diffuse);
+	specular = step(0.5, specular); // a hard highlight
+	#endif
+
+	// Schlick's Fresnel approximation with the specular color at normal incidence
+	vec3 fresnel = specularColor * amplitude.x + (1 - specularColor) * amplitude.y;
+	return diffuseColor * diffuse + fresnel * specular;
+}
+#endif
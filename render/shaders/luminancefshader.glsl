@@ -0,0 +1,15 @@
+#version 450
+
+in vec2 texCoord;
+
+uniform sampler2D inTexture;
+
+out vec4 fragColor;
+
+void main() {
+	vec3 color = texture(inTexture, texCoord).rgb;
+	float luminance = dot(color, vec3(0.2126, 0.7152, 0.0722));
+
+	// the mipmap averages the logarithm, giving the geometric mean
+	fragColor = vec4(log(luminance + 0.0001), 0, 0, 1);
+}
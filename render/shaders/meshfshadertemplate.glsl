@@ -25,6 +25,17 @@ in vec3 tanCameraToVertex;
 in vec4 lightSpacePosition;
 #endif
 
+#if defined(VELOCITY)
+in vec4 currentClipPosition;
+in vec4 previousClipPosition;
+#endif
+
+#if defined(CLUSTERED) || defined(SURFACE) || defined(AREA)
+in vec3 viewPositionF;
+in vec3 viewNormalF;
+in vec3 viewTangentF;
+#endif
+
 #if defined(DEPTH)
 uniform float materialAlpha; // TODO: let textures modify alpha
 uniform sampler2D materialAlphaMap;
@@ -36,7 +47,7 @@ uniform sampler2D materialAmbientMap;
 uniform sampler2D aoMap;
 #endif
 
-#if defined(POINT) || defined(SPOT) || defined(DIR)
+#if defined(POINT) || defined(SPOT) || defined(DIR) || defined(CLUSTERED) || defined(SURFACE) || defined(AREA)
 uniform vec3 materialDiffuse;
 uniform vec3 materialSpecular;
 uniform float materialShine;
@@ -55,7 +66,8 @@ uniform vec3 lightColor;
 uniform vec3 lightPosition;
 uniform vec3 lightColor;
 uniform float lightFar;
-uniform float lightAttenuation;
+uniform vec3 lightAttenuation; // constant, linear and quadratic
+uniform float lightRange;
 #endif
 
 #if defined(SPOT)
@@ -63,14 +75,69 @@ uniform vec3 lightPosition;
 uniform vec3 lightDirection;
 uniform vec3 lightColor;
 uniform float lightFar;
-uniform float lightAttenuation;
+uniform vec3 lightAttenuation; // constant, linear and quadratic
+uniform float lightRange;
 uniform float lightCosAng;
+uniform float lightCosInnerAng;
+uniform sampler2D lightCookie;
+uniform mat4 cookieProjectionViewMatrix;
 #endif
 
 #if defined(DIR)
 uniform vec3 lightDirection;
 uniform vec3 lightColor;
-uniform float lightAttenuation;
+uniform vec3 lightAttenuation; // constant, linear and quadratic
+uniform float lightRange;
+#endif
+
+#if defined(AREA)
+uniform vec3 lightPoints[16]; // MAX_AREA_POINTS in view space, clockwise seen from the front
+uniform int lightPointCount;
+uniform vec3 lightNormal; // view space
+uniform vec3 lightPosition; // view space center
+uniform vec3 lightColor;
+uniform float lightRange;
+uniform bool lightTwoSided;
+uniform sampler2D ltcMatrixMap;
+uniform sampler2D ltcAmplitudeMap;
+#endif
+
+#if defined(CLUSTERED)
+// must match the constants in render/cluster.go
+const int clusterTilesX = 16;
+const int clusterTilesY = 9;
+const int clusterSlices = 24;
+
+const float pointLight = 0;
+const float spotLight = 1;
+
+struct Light {
+	vec3 position; // view space
+	float type;
+	vec3 color;
+	float range;
+	vec3 direction; // view space
+	float cosAngle; // of the outer cone
+	vec3 attenuation; // constant, linear and quadratic
+	float cosInnerAngle;
+};
+
+layout(std430) readonly buffer lightBuffer {
+	Light lights[];
+};
+
+layout(std430) readonly buffer clusterBuffer {
+	uvec2 clusters[]; // offset and count into lightIndices
+};
+
+layout(std430) readonly buffer lightIndexBuffer {
+	uint lightIndices[];
+};
+
+uniform float clusterNear;
+uniform float clusterFar;
+uniform int viewportWidth;
+uniform int viewportHeight;
 #endif
 
 #if defined(SHADOW)
@@ -84,6 +151,8 @@ uniform int kernelSize;
 #endif
 #endif
 
+#include "lighting.glsl"
+
 void main() {
 	#if defined(DEPTH)
 	float alpha = materialAlpha * texture(materialAlphaMap, texCoordF).r;
@@ -93,57 +162,141 @@ void main() {
 	}
 	#endif
 
+	#if defined(VELOCITY)
+	// motion since the previous frame in texture coordinates
+	vec2 current = currentClipPosition.xy / currentClipPosition.w;
+	vec2 previous = previousClipPosition.xy / previousClipPosition.w;
+	fragColor = vec4(0.5 * (current - previous), 0, 0);
+	#endif
+
 	#if defined(AMBIENT)
-	vec4 tex;
-	tex = texture(materialAmbientMap, texCoordF);
 	vec2 screenTexCoord = vec2(0.5) + 0.5 * projPosition.xy / projPosition.w;
 	float ao = texture(aoMap, screenTexCoord).r;
-	vec3 ambient = ((1 - tex.a) * materialAmbient + tex.a * tex.rgb)
+	vec3 ambient = materialColor(materialAmbient, materialAmbientMap, texCoordF)
 				 * ao
 				 * lightColor;
 	fragColor = vec4(ambient, 1);
 	#endif
 
 	#if defined(POINT) || defined(SPOT) || defined(DIR)
-	float dx = 1.0 / materialBumpMapWidth;
-	float dy = 1.0 / materialBumpMapHeight;
-	float z1 = texture(materialBumpMap, vec2(texCoordF.x-dx, texCoordF.y)).r;
-	float z2 = texture(materialBumpMap, vec2(texCoordF.x+dx, texCoordF.y)).r;
-	float dzdx = (z2-z1) * 10.0;
-	z1 = texture(materialBumpMap, vec2(texCoordF.x, texCoordF.y-dy)).r;
-	z2 = texture(materialBumpMap, vec2(texCoordF.x, texCoordF.y+dy)).r;
-	float dzdy = (z2-z1) * 10.0;
-	vec3 tanNormal = normalize(vec3(dzdx, dzdy, 2));
-
-	vec4 tex;
-	vec3 tanReflection = normalize(reflect(tanLightToVertex, tanNormal));
-	bool facing = dot(tanNormal, tanLightToVertex) < 0;
-
-	float attenuation = 1 / (1.0 + lightAttenuation * dot(tanLightToVertex, tanLightToVertex));
-
-	tex = texture(materialDiffuseMap, texCoordF);
-	vec3 diffuse = ((1 - tex.a) * materialDiffuse + tex.a * tex.rgb)
-				 * max(dot(tanNormal, normalize(-tanLightToVertex)), 0)
+	#if defined(NORMALMAP)
+	vec3 tanNormal = bumpMapNormal(materialBumpMap, texCoordF, materialBumpMapWidth, materialBumpMapHeight);
+	#else
+	vec3 tanNormal = vec3(0, 0, 1);
+	#endif
+
+	float attenuation = attenuationFactor(lightAttenuation, lightRange, tanLightToVertex);
+
+	vec3 diffuse = materialColor(materialDiffuse, materialDiffuseMap, texCoordF)
+				 * diffuseFactor(tanNormal, tanLightToVertex)
 				 * lightColor
 				 * attenuation;
 
-	tex = texture(materialSpecularMap, texCoordF);
-	vec3 specular = ((1 - tex.a) * materialSpecular + tex.a * tex.rgb)
-				  * pow(max(dot(tanReflection, -normalize(tanCameraToVertex)), 0), materialShine)
+	vec3 specular = materialColor(materialSpecular, materialSpecularMap, texCoordF)
+				  * specularFactor(tanNormal, tanLightToVertex, tanCameraToVertex, materialShine)
 				  * lightColor
-				  * (facing ? 1 : 0)
 				  * attenuation;
 
 	#if defined(SPOT)
-	if (dot(normalize(tanLightDirection), normalize(tanLightToVertex)) < lightCosAng)  {
-		diffuse = vec3(0, 0, 0);
-		specular = vec3(0, 0, 0);
-	}
+	vec4 cookiePosition = cookieProjectionViewMatrix * vec4(worldPosition, 1);
+	vec2 cookieTexCoord = vec2(0.5, 0.5) + 0.5 * cookiePosition.xy / cookiePosition.w;
+	vec3 cookie = texture(lightCookie, cookieTexCoord).rgb;
+	float spot = spotFactor(tanLightDirection, tanLightToVertex, lightCosInnerAng, lightCosAng);
+	diffuse *= spot * cookie;
+	specular *= spot * cookie;
 	#endif
 
 	fragColor = vec4(diffuse + specular, 1);
 	#endif
 
+	#if defined(CLUSTERED)
+	vec3 viewNormal = normalize(viewNormalF);
+	vec3 viewTangent = normalize(viewTangentF);
+	vec3 viewBitangent = normalize(cross(viewNormal, viewTangent));
+	mat3 viewToTan = transpose(mat3(viewTangent, viewBitangent, viewNormal));
+
+	#if defined(NORMALMAP)
+	vec3 tanNormal = bumpMapNormal(materialBumpMap, texCoordF, materialBumpMapWidth, materialBumpMapHeight);
+	#else
+	vec3 tanNormal = vec3(0, 0, 1);
+	#endif
+
+	vec3 tanCameraToVertex = viewToTan * viewPositionF;
+	vec3 diffuseColor = materialColor(materialDiffuse, materialDiffuseMap, texCoordF);
+	vec3 specularColor = materialColor(materialSpecular, materialSpecularMap, texCoordF);
+
+	int tileX = clamp(int(gl_FragCoord.x / viewportWidth * clusterTilesX), 0, clusterTilesX - 1);
+	int tileY = clamp(int(gl_FragCoord.y / viewportHeight * clusterTilesY), 0, clusterTilesY - 1);
+	float depth = -viewPositionF.z;
+	int slice = int(log(depth / clusterNear) / log(clusterFar / clusterNear) * clusterSlices);
+	slice = clamp(slice, 0, clusterSlices - 1);
+	uvec2 cluster = clusters[(slice * clusterTilesY + tileY) * clusterTilesX + tileX];
+
+	vec3 color = vec3(0, 0, 0);
+	for (uint i = cluster.x; i < cluster.x + cluster.y; i++) {
+		Light light = lights[lightIndices[i]];
+		vec3 viewLightToVertex = viewPositionF - light.position;
+
+		float spot = 1;
+		if (light.type == spotLight) {
+			spot = spotFactor(light.direction, viewLightToVertex, light.cosInnerAngle, light.cosAngle);
+			if (spot == 0) {
+				continue;
+			}
+		}
+
+		vec3 tanLightToVertex = viewToTan * viewLightToVertex;
+		float attenuation = attenuationFactor(light.attenuation, light.range, tanLightToVertex) * spot;
+		color += diffuseColor * diffuseFactor(tanNormal, tanLightToVertex) * light.color * attenuation;
+		color += specularColor * specularFactor(tanNormal, tanLightToVertex, tanCameraToVertex, materialShine) * light.color * attenuation;
+	}
+
+	fragColor = vec4(color, 1);
+	#endif
+
+	#if defined(AREA)
+	vec3 viewNormal = normalize(viewNormalF);
+	vec3 viewTangent = normalize(viewTangentF);
+	vec3 viewBitangent = normalize(cross(viewNormal, viewTangent));
+	mat3 tanToView = mat3(viewTangent, viewBitangent, viewNormal);
+
+	#if defined(NORMALMAP)
+	vec3 tanNormal = bumpMapNormal(materialBumpMap, texCoordF, materialBumpMapWidth, materialBumpMapHeight);
+	#else
+	vec3 tanNormal = vec3(0, 0, 1);
+	#endif
+
+	bool behind = dot(viewPositionF - lightPoints[0], lightNormal) < 0;
+	if (behind && !lightTwoSided) {
+		discard;
+	}
+
+	vec3 diffuseColor = materialColor(materialDiffuse, materialDiffuseMap, texCoordF);
+	vec3 specularColor = materialColor(materialSpecular, materialSpecularMap, texCoordF);
+	vec3 color = areaLighting(normalize(tanToView * tanNormal), normalize(-viewPositionF), viewPositionF,
+	                          lightPoints, lightPointCount, behind, diffuseColor, specularColor, materialShine,
+	                          ltcMatrixMap, ltcAmplitudeMap);
+	fragColor = vec4(color * lightColor * rangeWindow(length(viewPositionF - lightPosition), lightRange), 1);
+	#endif
+
+	#if defined(SURFACE)
+	// view space normal and reflectivity for screen space effects
+	vec3 viewNormal = normalize(viewNormalF);
+	vec3 viewTangent = normalize(viewTangentF);
+	vec3 viewBitangent = normalize(cross(viewNormal, viewTangent));
+	mat3 tanToView = mat3(viewTangent, viewBitangent, viewNormal);
+
+	#if defined(NORMALMAP)
+	vec3 tanNormal = bumpMapNormal(materialBumpMap, texCoordF, materialBumpMapWidth, materialBumpMapHeight);
+	#else
+	vec3 tanNormal = vec3(0, 0, 1);
+	#endif
+
+	vec3 specularColor = materialColor(materialSpecular, materialSpecularMap, texCoordF);
+	float reflectivity = max(specularColor.r, max(specularColor.g, specularColor.b));
+	fragColor = vec4(tanToView * tanNormal, reflectivity);
+	#endif
+
 	#if defined(SHADOW)
 
 	#if defined(POINT)
@@ -32,7 +32,21 @@ out vec3 tanCameraToVertex;
 out vec4 lightSpacePosition;
 #endif
 
-#if defined(POINT) || defined(SPOT) || defined(DIR)
+#if defined(VELOCITY)
+uniform mat4 previousModelMatrix;
+uniform mat4 projectionViewMatrix; // without jitter
+uniform mat4 previousProjectionViewMatrix;
+out vec4 currentClipPosition;
+out vec4 previousClipPosition;
+#endif
+
+#if defined(CLUSTERED) || defined(SURFACE) || defined(AREA)
+out vec3 viewPositionF;
+out vec3 viewNormalF;
+out vec3 viewTangentF;
+#endif
+
+#if defined(POINT) || defined(SPOT) || defined(DIR) || defined(CLUSTERED) || defined(SURFACE) || defined(AREA)
 uniform mat4 normalMatrix;
 #endif
 
@@ -50,7 +64,7 @@ uniform vec3 materialAmbient;
 uniform sampler2D materialAmbientMap;
 #endif
 
-#if defined(POINT) || defined(SPOT) || defined(DIR)
+#if defined(POINT) || defined(SPOT) || defined(DIR) || defined(CLUSTERED)
 uniform vec3 materialDiffuse;
 uniform vec3 materialSpecular;
 uniform float materialShine;
@@ -67,7 +81,8 @@ uniform vec3 lightColor;
 uniform vec3 lightPosition;
 uniform vec3 lightColor;
 uniform float lightFar;
-uniform float lightAttenuation;
+uniform vec3 lightAttenuation;
+uniform float lightRange;
 #endif
 
 #if defined(SPOT)
@@ -75,13 +90,15 @@ uniform vec3 lightPosition;
 uniform vec3 lightDirection;
 uniform vec3 lightColor;
 uniform float lightFar;
-uniform float lightAttenuation;
+uniform vec3 lightAttenuation;
+uniform float lightRange;
 #endif
 
 #if defined(DIR)
 uniform vec3 lightDirection;
 uniform vec3 lightColor;
-uniform float lightAttenuation;
+uniform vec3 lightAttenuation;
+uniform float lightRange;
 #endif
 
 void main() {
@@ -92,6 +109,11 @@ void main() {
 
 	texCoordF = texCoordV;
 
+	#if defined(VELOCITY)
+	currentClipPosition = projectionViewMatrix * vec4(worldPosition, 1);
+	previousClipPosition = previousProjectionViewMatrix * previousModelMatrix * vec4(position, 1);
+	#endif
+
 	#if defined(POINT) || defined(SPOT) || defined(DIR)
 	vec3 viewNormal = normalize(vec3(normalMatrix * vec4(normalV, 0)));
 	vec3 viewTangent = normalize(vec3(normalMatrix * vec4(tangentV, 0)));
@@ -109,6 +131,13 @@ void main() {
 	tanCameraToVertex = viewToTan * (viewPosition - vec3(0, 0, 0));
 	#endif
 
+	#if defined(CLUSTERED) || defined(SURFACE) || defined(AREA)
+	// clustered lights are many, so move to tangent space per fragment instead
+	viewPositionF = viewPosition;
+	viewNormalF = vec3(normalMatrix * vec4(normalV, 0));
+	viewTangentF = vec3(normalMatrix * vec4(tangentV, 0));
+	#endif
+
 	#if defined(SPOT)
 	vec3 viewLightDirection = vec3(viewMatrix * vec4(lightDirection, 0));
 	tanLightDirection = viewToTan * viewLightDirection;
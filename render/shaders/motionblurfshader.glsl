@@ -0,0 +1,49 @@
+#version 450
+
+in vec2 texCoord;
+
+uniform sampler2D inTexture;
+uniform sampler2D depthMap;
+uniform sampler2D velocityMap;
+uniform bool objectMotion;
+
+// without jitter
+uniform mat4 invProjectionViewMatrix;
+uniform mat4 previousProjectionViewMatrix;
+
+uniform float shutter; // fraction of the frame
+
+out vec4 fragColor;
+
+#define SAMPLE_COUNT 16
+#define MAX_LENGTH 0.05 // in texture coordinates
+
+void main() {
+	float depth = texture(depthMap, texCoord).r;
+
+	vec2 velocity;
+	if (objectMotion && depth < 1) {
+		velocity = texture(velocityMap, texCoord).xy;
+	} else {
+		// reproject the pixel to where the previous camera saw it
+		vec4 ndcPosition = vec4(-1.0 + 2.0 * texCoord, -1.0 + 2.0 * depth, 1);
+		vec4 worldPosition = invProjectionViewMatrix * ndcPosition;
+		vec4 previousPosition = previousProjectionViewMatrix * worldPosition;
+		vec2 previousTexCoord = 0.5 + 0.5 * previousPosition.xy / previousPosition.w;
+		velocity = texCoord - previousTexCoord;
+	}
+
+	velocity *= shutter;
+	if (length(velocity) > MAX_LENGTH) {
+		velocity *= MAX_LENGTH / length(velocity);
+	}
+
+	// average along the motion centered on the pixel
+	vec3 sum = vec3(0);
+	for (int i = 0; i < SAMPLE_COUNT; i++) {
+		float t = float(i) / (SAMPLE_COUNT - 1) - 0.5;
+		sum += texture(inTexture, texCoord + t * velocity).rgb;
+	}
+
+	fragColor = vec4(sum / SAMPLE_COUNT, 1);
+}
@@ -0,0 +1,18 @@
+#version 450
+
+uniform vec3 boxPosition;
+uniform vec3 boxSize;
+
+uniform mat4 viewMatrix;
+uniform mat4 projectionMatrix;
+
+void main() {
+	// pick the corners of a 14 vertex unit cube triangle strip from bit masks
+	int bit = 1 << gl_VertexID;
+	float x = float((0x287a & bit) != 0);
+	float y = float((0x02af & bit) != 0);
+	float z = float((0x31e3 & bit) != 0);
+
+	vec3 worldPosition = boxPosition + vec3(x, y, z) * boxSize;
+	gl_Position = projectionMatrix * viewMatrix * vec4(worldPosition, 1);
+}
@@ -0,0 +1,50 @@
+#version 450
+
+in vec2 texCoord;
+
+uniform sampler2D inTexture;
+uniform sampler2D depthMap;
+uniform sampler2D normalMap; // geometric view space normals
+uniform bool hasNormals;
+uniform mat4 invProjectionMatrix;
+
+uniform vec3 outlineColor;
+uniform float thickness; // in pixels
+uniform float depthThreshold; // relative to the depth
+uniform float normalThreshold;
+
+out vec4 fragColor;
+
+float viewDepth(vec2 texCoord) {
+	float depth = texture(depthMap, texCoord).r;
+	vec4 position = invProjectionMatrix * vec4(-1.0 + 2.0 * texCoord, -1.0 + 2.0 * depth, 1.0);
+	return -position.z / position.w;
+}
+
+void main() {
+	vec4 color = texture(inTexture, texCoord);
+
+	// compare diagonal neighbours (the Roberts cross)
+	vec2 texel = thickness / vec2(textureSize(inTexture, 0));
+	vec2 tc1 = texCoord + vec2(-0.5, -0.5) * texel;
+	vec2 tc2 = texCoord + vec2(+0.5, +0.5) * texel;
+	vec2 tc3 = texCoord + vec2(-0.5, +0.5) * texel;
+	vec2 tc4 = texCoord + vec2(+0.5, -0.5) * texel;
+
+	float depth = viewDepth(texCoord);
+	float depthEdge = (abs(viewDepth(tc1) - viewDepth(tc2)) + abs(viewDepth(tc3) - viewDepth(tc4))) / depth;
+	float edge = depthEdge > depthThreshold ? 1.0 : 0.0;
+
+	if (hasNormals) {
+		vec3 n1 = texture(normalMap, tc1).xyz;
+		vec3 n2 = texture(normalMap, tc2).xyz;
+		vec3 n3 = texture(normalMap, tc3).xyz;
+		vec3 n4 = texture(normalMap, tc4).xyz;
+		float normalEdge = length(n1 - n2) + length(n3 - n4);
+		if (normalEdge > normalThreshold) {
+			edge = 1.0;
+		}
+	}
+
+	fragColor = vec4(mix(color.rgb, outlineColor, edge), color.a);
+}
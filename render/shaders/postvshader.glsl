@@ -0,0 +1,12 @@
+#version 450
+
+// shared by post processing effects that draw a full screen quad
+
+in vec2 position;
+
+out vec2 texCoord;
+
+void main() {
+	texCoord = 0.5 + 0.5 * position;
+	gl_Position = vec4(position, 0, 1);
+}
@@ -0,0 +1,12 @@
+#version 450
+
+in vec2 texCoord;
+
+uniform sampler2D inTexture;
+uniform float factor;
+
+out vec4 fragColor;
+
+void main() {
+	fragColor = vec4(factor * texture(inTexture, texCoord).rgb, 1);
+}
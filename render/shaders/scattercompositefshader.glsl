@@ -0,0 +1,46 @@
+#version 450
+
+in vec2 texCoord;
+
+uniform sampler2D inTexture;
+uniform sampler2D scatterMap; // lower resolution
+uniform sampler2D depthMap;
+uniform mat4 invProjectionMatrix;
+
+out vec4 fragColor;
+
+const float SHARPNESS = 20.0;
+
+float viewDepth(vec2 texCoord) {
+	float depth = texture(depthMap, texCoord).r;
+	vec4 position = invProjectionMatrix * vec4(-1.0 + 2.0 * texCoord, -1.0 + 2.0 * depth, 1.0);
+	return -position.z / position.w;
+}
+
+// upsample bilinearly, but weigh down the low resolution pixels
+// that are at other depths, so light does not bleed over edges
+void main() {
+	vec2 size = vec2(textureSize(scatterMap, 0));
+	vec2 coord = texCoord * size - 0.5;
+	vec2 base = floor(coord);
+	vec2 f = coord - base;
+	float centerDepth = viewDepth(texCoord);
+
+	vec3 result = vec3(0, 0, 0);
+	float weightSum = 0.0;
+	for (int j = 0; j <= 1; j++) {
+		for (int i = 0; i <= 1; i++) {
+			vec2 sampleTexCoord = (base + vec2(i, j) + 0.5) / size;
+			float depth = viewDepth(sampleTexCoord);
+
+			float bilinearWeight = (i == 0 ? 1.0 - f.x : f.x) * (j == 0 ? 1.0 - f.y : f.y);
+			float depthWeight = exp(-SHARPNESS * abs(depth - centerDepth) / centerDepth);
+			float weight = bilinearWeight * depthWeight + 0.0001;
+
+			result += weight * texture(scatterMap, sampleTexCoord).rgb;
+			weightSum += weight;
+		}
+	}
+
+	fragColor = texture(inTexture, texCoord) + vec4(result / weightSum, 0);
+}
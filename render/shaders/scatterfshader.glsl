@@ -0,0 +1,94 @@
+#version 450
+
+in vec2 texCoord;
+
+uniform sampler2D depthMap;
+uniform mat4 invProjectionMatrix;
+uniform mat4 invViewMatrix;
+
+// must match the constants in render/effect.go
+#define SPOT_LIGHT 0
+#define DIR_LIGHT 1
+
+uniform int lightType;
+uniform vec3 lightPosition;
+uniform vec3 lightDirection;
+uniform vec3 lightColor;
+uniform vec3 lightAttenuation;
+uniform float lightRange;
+uniform float lightCosAngle;
+uniform float lightCosInnerAngle;
+uniform float lightFar;
+uniform mat4 shadowProjectionViewMatrix;
+uniform sampler2D shadowMap;
+
+uniform int steps;
+uniform float density;
+uniform float anisotropy;
+uniform float maxDistance;
+
+out vec4 fragColor;
+
+#include "lighting.glsl"
+
+const float PI = 3.14159265;
+
+// Henyey-Greenstein phase function
+float phase(float cosAngle) {
+	float g2 = anisotropy * anisotropy;
+	return (1.0 - g2) / (4.0 * PI * pow(1.0 + g2 - 2.0 * anisotropy * cosAngle, 1.5));
+}
+
+// fraction of the light that reaches a point in the air
+float visibility(vec3 position) {
+	vec4 lightSpacePosition = shadowProjectionViewMatrix * vec4(position, 1);
+	vec3 ndcCoords = lightSpacePosition.xyz / lightSpacePosition.w;
+	vec2 texCoordS = vec2(0.5, 0.5) + 0.5 * ndcCoords.xy;
+	float depthFront = texture(shadowMap, texCoordS).r;
+
+	if (lightType == SPOT_LIGHT) {
+		vec3 lightToPosition = position - lightPosition;
+		float dist = length(lightToPosition);
+		float spot = spotFactor(lightDirection, lightToPosition, lightCosInnerAngle, lightCosAngle);
+		float attenuation = attenuationFactor(lightAttenuation, lightRange, lightToPosition);
+		return float(dist <= depthFront * lightFar + 0.1) * spot * attenuation;
+	} else {
+		float depth = 0.5 + 0.5 * ndcCoords.z;
+		return float(depth <= depthFront + 0.005);
+	}
+}
+
+// march along the view ray and add up the light scattered toward the camera
+void main() {
+	float depth = texture(depthMap, texCoord).r;
+	vec4 viewPosition = invProjectionMatrix * vec4(-1.0 + 2.0 * texCoord, -1.0 + 2.0 * depth, 1.0);
+	vec3 end = vec3(invViewMatrix * vec4(viewPosition.xyz / viewPosition.w, 1));
+	vec3 start = vec3(invViewMatrix * vec4(0, 0, 0, 1));
+
+	vec3 ray = end - start;
+	float dist = min(length(ray), maxDistance);
+	vec3 direction = normalize(ray);
+	float stepLength = dist / float(steps);
+
+	// offset the samples differently in neighbouring pixels to trade banding
+	// for noise, which the upsampling smooths out
+	float noise = fract(52.9829189 * fract(dot(gl_FragCoord.xy, vec2(0.06711056, 0.00583715))));
+
+	float cosAngle = 0.0;
+	if (lightType == DIR_LIGHT) {
+		cosAngle = dot(lightDirection, -direction);
+	}
+
+	vec3 scattered = vec3(0, 0, 0);
+	for (int i = 0; i < steps; i++) {
+		float t = (float(i) + noise) * stepLength;
+		vec3 position = start + t * direction;
+		if (lightType == SPOT_LIGHT) {
+			cosAngle = dot(normalize(position - lightPosition), -direction);
+		}
+		float transmittance = exp(-density * t);
+		scattered += visibility(position) * phase(cosAngle) * density * transmittance * stepLength;
+	}
+
+	fragColor = vec4(scattered * lightColor, 1);
+}
@@ -6,6 +6,18 @@ out vec4 fragColor;
 
 uniform samplerCube cubeMap;
 
+#if defined(VELOCITY)
+in vec4 currentClipPosition;
+in vec4 previousClipPosition;
+#endif
+
 void main() {
+	#if defined(VELOCITY)
+	// motion since the previous frame in texture coordinates
+	vec2 current = currentClipPosition.xy / currentClipPosition.w;
+	vec2 previous = previousClipPosition.xy / previousClipPosition.w;
+	fragColor = vec4(0.5 * (current - previous), 0, 0);
+	#else
 	fragColor = vec4(texture(cubeMap, positionF).rgb, 1);
+	#endif
 }
@@ -8,7 +8,21 @@ uniform mat4 modelMatrix;
 uniform mat4 viewMatrix;
 uniform mat4 projectionMatrix;
 
+#if defined(VELOCITY)
+uniform mat4 projectionViewMatrix;
+uniform mat4 previousProjectionViewMatrix;
+
+out vec4 currentClipPosition;
+out vec4 previousClipPosition;
+#endif
+
 void main() {
 	positionF = positionV;
 	gl_Position = projectionMatrix * mat4(mat3(viewMatrix)) * vec4(positionV, 1.0);
+
+	#if defined(VELOCITY)
+	// the sky is infinitely far away, so only rotating the camera moves it
+	currentClipPosition = projectionViewMatrix * vec4(positionV, 0);
+	previousClipPosition = previousProjectionViewMatrix * vec4(positionV, 0);
+	#endif
 }
@@ -3,31 +3,38 @@
 in vec2 texCoord;
 
 uniform sampler2D aoMap;
-uniform int aoMapWidth;
-uniform int aoMapHeight;
-
 uniform sampler2D depthMap;
-uniform int depthWidth;
-uniform int depthHeight;
+uniform mat4 invProjectionMatrix;
+uniform vec2 direction; // one texel of the ao map along the blur
 
 out vec4 fragColor;
 
-const int SAMPLE_SIZE = 8;
+const int RADIUS = 4;
+const float SHARPNESS = 40.0;
+
+float viewDepth(vec2 texCoord) {
+	float depth = texture(depthMap, texCoord).r;
+	vec4 position = invProjectionMatrix * vec4(-1.0 + 2.0 * texCoord, -1.0 + 2.0 * depth, 1.0);
+	return -position.z / position.w;
+}
 
+// blur in one direction, but not across depth discontinuities
 void main() {
-	float dx = 1.0 / aoMapWidth;
-	float dy = 1.0 / aoMapHeight;
+	float centerDepth = viewDepth(texCoord);
 
 	float result = 0.0;
+	float weightSum = 0.0;
+	for (int i = -RADIUS; i <= +RADIUS; i++) {
+		vec2 sampleTexCoord = texCoord + float(i) * direction;
+		float depth = viewDepth(sampleTexCoord);
 
-	vec2 tl = texCoord - vec2(float(SAMPLE_SIZE) / 2.0 * dx, float(SAMPLE_SIZE) / 2.0 * dy);
+		float spatialWeight = exp(-2.0 * float(i * i) / float(RADIUS * RADIUS));
+		float depthWeight = exp(-SHARPNESS * abs(depth - centerDepth) / centerDepth);
+		float weight = spatialWeight * depthWeight;
 
-	for (int x = 0; x < SAMPLE_SIZE; x++) {
-		for (int y = 0; y < SAMPLE_SIZE; y++) {
-			result += texture(aoMap, tl + vec2(x * dx, y * dy)).r;
-		}
+		result += weight * texture(aoMap, sampleTexCoord).r;
+		weightSum += weight;
 	}
-	result /= (SAMPLE_SIZE * SAMPLE_SIZE);
 
-	fragColor = vec4(result, 0, 0, 0);
+	fragColor = vec4(result / weightSum, 0, 0, 0);
 }
@@ -3,43 +3,61 @@
 out vec4 fragColor;
 in vec2 texCoord;
 uniform sampler2D depthMap;
-uniform int depthMapWidth;
-uniform int depthMapHeight;
 uniform mat4 projectionMatrix;
 uniform mat4 invProjectionMatrix;
-uniform vec3 [16]directions;
 uniform sampler2D directionMap;
 
-const float DIRECTION_LENGTH = 0.5;
+#define MAX_SAMPLES 64 // must match render.ssaoMaxSamples
+
+uniform vec3 [MAX_SAMPLES]directions; // in a hemisphere around +z
+uniform int sampleCount;
+uniform float radius;
+uniform float bias;
+uniform float power;
+
+vec3 viewPosition(vec2 texCoord) {
+	float depth = texture(depthMap, texCoord).r;
+	vec4 position = invProjectionMatrix * vec4(-1.0 + 2.0 * texCoord, -1.0 + 2.0 * depth, 1.0);
+	return position.xyz / position.w;
+}
+
+// reconstruct the normal from the neighbours closest in depth,
+// so normals along edges do not mix different surfaces
+vec3 viewNormal(vec2 texCoord, vec3 center) {
+	vec2 texel = 1.0 / vec2(textureSize(depthMap, 0));
+	vec3 left = viewPosition(texCoord - vec2(texel.x, 0));
+	vec3 right = viewPosition(texCoord + vec2(texel.x, 0));
+	vec3 down = viewPosition(texCoord - vec2(0, texel.y));
+	vec3 up = viewPosition(texCoord + vec2(0, texel.y));
+
+	vec3 dx = abs(right.z - center.z) < abs(center.z - left.z) ? right - center : center - left;
+	vec3 dy = abs(up.z - center.z) < abs(center.z - down.z) ? up - center : center - down;
+	return normalize(cross(dx, dy));
+}
 
 void main() {
-	float x = -1.0 + 2.0 * texCoord.x;
-	float y = -1.0 + 2.0 * texCoord.y;
-	float z = texture(depthMap, texCoord).r;
-	vec4 viewPosition = invProjectionMatrix * vec4(x, y, z, 1.0);
-	viewPosition /= viewPosition.w; // perspective divide
-
-	float fraction = 0.0;
-	for (int i = 0; i < 16; i++) {
-		vec3 direction = texture(directionMap, vec2(texCoord.x + 0.9 * float(i), texCoord.y)).xyz;
-		direction *= abs(direction.x);
-		vec4 viewPos = viewPosition + vec4(direction * DIRECTION_LENGTH, 0);
-		vec4 projPos = projectionMatrix * viewPos;
-		projPos /= projPos.w; // perspective divide
-		float depth = texture(depthMap, vec2(0.5) + 0.5 * projPos.xy).r;
-
-		float x2 = projPos.x;
-		float y2 = projPos.y;
-		float z2 = depth;
-		vec4 viewPosition2 = invProjectionMatrix * vec4(x2, y2, z2, 1.0);
-		viewPosition2 /= viewPosition2.w; // perspective divide
-
-		fraction += length(viewPos) > length(viewPosition2) ? 1.0 / 16.0 : 0.0;
+	vec3 position = viewPosition(texCoord);
+	vec3 normal = viewNormal(texCoord, position);
+
+	// orient the hemisphere along the normal, randomly rotated around it
+	vec3 random = texture(directionMap, texCoord).xyz;
+	vec3 tangent = normalize(random - normal * dot(random, normal));
+	vec3 bitangent = cross(normal, tangent);
+	mat3 tbn = mat3(tangent, bitangent, normal);
+
+	float occlusion = 0.0;
+	for (int i = 0; i < sampleCount; i++) {
+		vec3 samplePosition = position + radius * (tbn * directions[i]);
+		vec4 projPosition = projectionMatrix * vec4(samplePosition, 1.0);
+		vec2 sampleTexCoord = vec2(0.5) + 0.5 * projPosition.xy / projPosition.w;
+		float sceneDepth = viewPosition(sampleTexCoord).z;
+
+		// ignore occluders much farther away than the radius
+		float rangeCheck = smoothstep(0.0, 1.0, radius / abs(position.z - sceneDepth));
+		occlusion += (sceneDepth >= samplePosition.z + bias ? 1.0 : 0.0) * rangeCheck;
 	}
 
-	fraction = 1 - fraction;
-
-	fraction = pow(1.0 * (0.5 + fraction), 1.0);
+	float ao = pow(1.0 - occlusion / float(sampleCount), power);
 
-	fragColor = vec4(vec3(fraction), 1.0);
+	fragColor = vec4(vec3(ao), 1.0);
 }
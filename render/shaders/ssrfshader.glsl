@@ -0,0 +1,101 @@
+#version 450
+
+in vec2 texCoord;
+
+uniform sampler2D inTexture;
+uniform sampler2D depthMap;
+uniform sampler2D surfaceMap; // view space normal and reflectivity
+uniform samplerCube skybox;
+uniform bool hasSkybox;
+
+uniform mat4 projectionMatrix;
+uniform mat4 invProjectionMatrix;
+uniform mat4 invViewMatrix;
+
+uniform int maxSteps;
+uniform int refineSteps;
+uniform float stepSize; // in view space
+uniform float thickness; // of surfaces the ray can pass behind
+uniform float intensity;
+uniform bool debug; // show only the reflections
+
+out vec4 fragColor;
+
+vec3 viewPosition(vec2 texCoord) {
+	float depth = texture(depthMap, texCoord).r;
+	vec4 position = invProjectionMatrix * vec4(-1.0 + 2.0 * texCoord, -1.0 + 2.0 * depth, 1.0);
+	return position.xyz / position.w;
+}
+
+vec2 project(vec3 viewPosition) {
+	vec4 position = projectionMatrix * vec4(viewPosition, 1.0);
+	return vec2(0.5) + 0.5 * position.xy / position.w;
+}
+
+bool onScreen(vec2 texCoord) {
+	return all(greaterThanEqual(texCoord, vec2(0))) && all(lessThanEqual(texCoord, vec2(1)));
+}
+
+void main() {
+	vec4 color = texture(inTexture, texCoord);
+	vec4 surface = texture(surfaceMap, texCoord);
+	float reflectivity = surface.a * intensity;
+
+	if (reflectivity <= 0 || texture(depthMap, texCoord).r == 1) {
+		fragColor = debug ? vec4(0, 0, 0, 1) : color;
+		return;
+	}
+
+	vec3 position = viewPosition(texCoord);
+	vec3 normal = normalize(surface.xyz);
+	vec3 direction = normalize(reflect(normalize(position), normal));
+
+	vec3 fallback = vec3(0);
+	if (hasSkybox) {
+		fallback = texture(skybox, mat3(invViewMatrix) * direction).rgb;
+	}
+
+	// march along the reflected ray until it passes behind the depth buffer
+	vec3 rayPosition = position;
+	vec3 step = direction * stepSize;
+	vec2 hitTexCoord = texCoord;
+	bool hit = false;
+	for (int i = 0; i < maxSteps; i++) {
+		rayPosition += step;
+		hitTexCoord = project(rayPosition);
+		if (!onScreen(hitTexCoord) || rayPosition.z > 0) {
+			break;
+		}
+
+		float behind = viewPosition(hitTexCoord).z - rayPosition.z;
+		if (behind > 0 && behind < thickness) {
+			// binary search for the intersection
+			for (int j = 0; j < refineSteps; j++) {
+				step *= 0.5;
+				rayPosition += behind > 0 ? -step : +step;
+				hitTexCoord = project(rayPosition);
+				behind = viewPosition(hitTexCoord).z - rayPosition.z;
+			}
+			hit = true;
+			break;
+		}
+	}
+
+	vec3 reflection = fallback;
+	if (hit) {
+		// fade to the fallback where the rays leave the screen
+		vec2 edge = smoothstep(0.0, 0.1, hitTexCoord) * (1.0 - smoothstep(0.9, 1.0, hitTexCoord));
+		reflection = mix(fallback, texture(inTexture, hitTexCoord).rgb, edge.x * edge.y);
+	}
+
+	// replace the surface color with the reflection, more so at grazing
+	// angles by Schlick's approximation of the Fresnel factor
+	float cosAngle = clamp(dot(-normalize(position), normal), 0, 1);
+	float fresnel = clamp(reflectivity + (1 - reflectivity) * pow(1 - cosAngle, 5), 0, 1);
+
+	if (debug) {
+		fragColor = vec4(fresnel * reflection, 1);
+	} else {
+		fragColor = vec4(mix(color.rgb, reflection, fresnel), color.a);
+	}
+}
@@ -0,0 +1,43 @@
+#version 450
+
+in vec2 texCoord;
+
+uniform sampler2D currentTexture;
+uniform sampler2D historyTexture;
+uniform sampler2D velocityMap;
+uniform bool historyValid;
+uniform float blend; // weight of the current frame
+
+out vec4 fragColor;
+
+void main() {
+	vec4 current = texture(currentTexture, texCoord);
+	if (!historyValid) {
+		fragColor = current;
+		return;
+	}
+
+	vec2 velocity = texture(velocityMap, texCoord).xy;
+	vec2 previousTexCoord = texCoord - velocity;
+
+	// clamp the history to the colors around the pixel to reject stale history
+	vec2 texel = 1.0 / vec2(textureSize(currentTexture, 0));
+	vec3 minColor = current.rgb;
+	vec3 maxColor = current.rgb;
+	for (int x = -1; x <= +1; x++) {
+		for (int y = -1; y <= +1; y++) {
+			vec3 color = texture(currentTexture, texCoord + vec2(x, y) * texel).rgb;
+			minColor = min(minColor, color);
+			maxColor = max(maxColor, color);
+		}
+	}
+	vec3 history = clamp(texture(historyTexture, previousTexCoord).rgb, minColor, maxColor);
+
+	// there is no history for pixels that were outside the screen
+	float weight = blend;
+	if (any(lessThan(previousTexCoord, vec2(0))) || any(greaterThan(previousTexCoord, vec2(1)))) {
+		weight = 1;
+	}
+
+	fragColor = vec4(mix(history, current.rgb, weight), current.a);
+}
@@ -0,0 +1,54 @@
+#version 450
+
+in vec2 texCoord;
+
+uniform sampler2D inTexture;
+uniform sampler2D luminanceMap;
+uniform int operator;
+uniform float exposure;
+uniform bool autoExposure;
+
+out vec4 fragColor;
+
+// must match the ToneMapOperator constants in render/render.go
+const int noToneMapping = 0;
+const int reinhardToneMapping = 1;
+const int acesToneMapping = 2;
+
+vec3 reinhard(vec3 color) {
+	return color / (1 + color);
+}
+
+// fit of the ACES filmic curve by Krzysztof Narkowicz
+vec3 aces(vec3 color) {
+	const float a = 2.51;
+	const float b = 0.03;
+	const float c = 2.43;
+	const float d = 0.59;
+	const float e = 0.14;
+	return clamp((color * (a * color + b)) / (color * (c * color + d) + e), 0, 1);
+}
+
+void main() {
+	vec4 hdr = texture(inTexture, texCoord);
+
+	float scale = exposure;
+	if (autoExposure) {
+		float luminance = texture(luminanceMap, vec2(0.5)).r;
+		scale *= 0.18 / max(luminance, 0.0001); // map average to middle gray
+	}
+	vec3 color = scale * hdr.rgb;
+
+	switch (operator) {
+	case reinhardToneMapping:
+		color = reinhard(color);
+		break;
+	case acesToneMapping:
+		color = aces(color);
+		break;
+	default:
+		color = clamp(color, 0, 1);
+	}
+
+	fragColor = vec4(color, hdr.a);
+}
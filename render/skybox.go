@@ -12,12 +12,18 @@ type SkyboxProgram struct {
 	ViewMatrix       *graphics.Uniform
 	ProjectionMatrix *graphics.Uniform
 	CubeMap          *graphics.Uniform
+
+	// for writing velocities
+	ProjectionViewMatrix         *graphics.Uniform
+	PreviousProjectionViewMatrix *graphics.Uniform
+
 	Position         *graphics.Input
 	Color            *graphics.Output
 }
 
 type SkyboxRenderer struct {
 	sp          *SkyboxProgram
+	velocitySp  *SkyboxProgram
 	vbo         *graphics.VertexBuffer
 	ibo         *graphics.IndexBuffer
 	tex         *graphics.CubeMap
@@ -25,17 +31,19 @@ type SkyboxRenderer struct {
 	cubemaps    map[*scene.CubeMap]*graphics.CubeMap
 }
 
-func NewSkyboxProgram() *SkyboxProgram {
+func NewSkyboxProgram(defines ...string) *SkyboxProgram {
 	var sp SkyboxProgram
 
-	vShaderFilename := "render/shaders/skyboxvshader.glsl" // TODO: make independent from executable directory
-	fShaderFilename := "render/shaders/skyboxfshader.glsl" // TODO: make independent from executable directory
+	vShaderFilename := "skyboxvshader.glsl"
+	fShaderFilename := "skyboxfshader.glsl"
 
-	sp.Program = graphics.ReadProgram(vShaderFilename, fShaderFilename, "")
+	sp.Program = readProgram(vShaderFilename, fShaderFilename, "", defines...)
 
 	sp.ViewMatrix = sp.UniformByName("viewMatrix")
 	sp.ProjectionMatrix = sp.UniformByName("projectionMatrix")
 	sp.CubeMap = sp.UniformByName("cubeMap")
+	sp.ProjectionViewMatrix = sp.UniformByName("projectionViewMatrix")
+	sp.PreviousProjectionViewMatrix = sp.UniformByName("previousProjectionViewMatrix")
 	sp.Position = sp.InputByName("positionV")
 	sp.Color = sp.OutputColorByName("fragColor")
 
@@ -48,6 +56,7 @@ func NewSkyboxRenderer() *SkyboxRenderer {
 	r.cubemaps = make(map[*scene.CubeMap]*graphics.CubeMap)
 
 	r.sp = NewSkyboxProgram()
+	r.velocitySp = NewSkyboxProgram("VELOCITY")
 
 	r.vbo = graphics.NewVertexBuffer()
 	verts := []math.Vec3{
@@ -87,6 +96,11 @@ func (r *SkyboxRenderer) setCamera(c camera.Camera) {
 }
 
 func (r *SkyboxRenderer) setSkybox(skybox *scene.CubeMap) {
+	r.sp.CubeMap.Set(r.cubeMap(skybox))
+}
+
+// cubeMap returns the texture of a skybox, loading it the first time
+func (r *SkyboxRenderer) cubeMap(skybox *scene.CubeMap) *graphics.CubeMap {
 	cm, found := r.cubemaps[skybox]
 	if !found {
 		img1 := skybox.Posx
@@ -98,19 +112,32 @@ func (r *SkyboxRenderer) setSkybox(skybox *scene.CubeMap) {
 		cm = graphics.LoadCubeMap(graphics.NearestFilter, img1, img2, img3, img4, img5, img6)
 		r.cubemaps[skybox] = cm
 	}
-
-	r.sp.CubeMap.Set(cm)
+	return cm
 }
 
 func (r *SkyboxRenderer) setCube(vbo *graphics.VertexBuffer, ibo *graphics.IndexBuffer) {
-	r.sp.Position.SetSourceVertex(vbo, 0)
-	r.sp.SetIndices(ibo)
+	for _, sp := range []*SkyboxProgram{r.sp, r.velocitySp} {
+		sp.Position.SetSourceVertex(vbo, 0)
+		sp.SetIndices(ibo)
+	}
 }
 
-func (r *SkyboxRenderer) Render(sb *scene.CubeMap, c camera.Camera, target *graphics.Texture2D) {
+func (r *SkyboxRenderer) Render(sb *scene.CubeMap, c camera.Camera, target graphics.RenderTarget) {
 	r.setSkybox(sb)
 	r.setCamera(c)
 	r.sp.Color.Set(target)
 
 	r.sp.Render(36, r.renderOpts)
 }
+
+// RenderVelocity writes the motion of the sky since the previous frame,
+// given the unjittered projection view matrices of both frames
+func (r *SkyboxRenderer) RenderVelocity(c camera.Camera, projViewMat, previousProjViewMat *math.Mat4, target graphics.RenderTarget) {
+	r.velocitySp.ViewMatrix.Set(c.ViewMatrix())
+	r.velocitySp.ProjectionMatrix.Set(c.ProjectionMatrix())
+	r.velocitySp.ProjectionViewMatrix.Set(projViewMat)
+	r.velocitySp.PreviousProjectionViewMatrix.Set(previousProjViewMat)
+	r.velocitySp.Color.Set(target)
+
+	r.velocitySp.Render(36, r.renderOpts)
+}
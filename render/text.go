@@ -37,9 +37,9 @@ const (
 func NewTextProgram() *TextProgram {
 	var sp TextProgram
 
-	vShaderFilename := "render/shaders/textvshader.glsl" // TODO: make independent from executable directory
-	fShaderFilename := "render/shaders/textfshader.glsl" // TODO: make independent from executable directory
-	sp.Program = graphics.ReadProgram(vShaderFilename, fShaderFilename, "")
+	vShaderFilename := "textvshader.glsl"
+	fShaderFilename := "textfshader.glsl"
+	sp.Program = readProgram(vShaderFilename, fShaderFilename, "")
 
 	sp.Atlas = sp.UniformByName("fontAtlas")
 	sp.Position = sp.InputByName("position")
@@ -80,6 +80,9 @@ func (r *TextRenderer) SetInputs(vbo *graphics.VertexBuffer, ibo *graphics.Index
 }
 
 func (r *TextRenderer) Render(org math.Vec2, text string, height float32, color math.Vec3, just Justification, target *graphics.Texture2D) {
+	graphics.BeginPass("text")
+	defer graphics.EndPass()
+
 	var verts []object.Vertex
 	var inds []int32
 
@@ -23,6 +23,7 @@ type Scene struct {
 	SpotLights        []*light.SpotLight
 	PointLights       []*light.PointLight
 	DirectionalLights []*light.DirectionalLight
+	AreaLights        []*light.AreaLight
 	Skybox            *CubeMap
 }
 
@@ -80,6 +81,10 @@ func (s *Scene) AddSpotLight(l *light.SpotLight) {
 	s.SpotLights = append(s.SpotLights, l)
 }
 
+func (s *Scene) AddAreaLight(l *light.AreaLight) {
+	s.AreaLights = append(s.AreaLights, l)
+}
+
 func (s *Scene) AddDirectionalLight(l *light.DirectionalLight) {
 	s.DirectionalLights = append(s.DirectionalLights, l)
 }
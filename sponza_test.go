@@ -15,18 +15,18 @@ func TestMain(m *testing.M) {
 
 	l1 := light.NewPointLight(math.Vec3{1, 1, 1})
 	l1.Place(math.Vec3{+15, 15, 0})
-	l1.Attenuation = 0.005
+	l1.AttenuationQuadratic = 0.005
 	l1.CastShadows = true
 
 	l2 := light.NewSpotLight(math.Vec3{1, 0, 0})
 	l2.Place(math.Vec3{0, 1, 0})
-	l2.Attenuation = 0.01
+	l2.AttenuationQuadratic = 0.01
 	l2.CastShadows = true
 	l2.FOV = 3.1415 / 4
 
 	l3 := light.NewPointLight(math.Vec3{0, 1, 0})
 	l3.Place(math.Vec3{22.59, 2.40, -9.18})
-	l3.Attenuation = 0.05
+	l3.AttenuationQuadratic = 0.05
 	l3.CastShadows = true
 
 	eng := engine.NewEngine()